@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/ruiborda/go-swagger-generator/src/middleware"
 	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/reconcile"
 	"github.com/ruiborda/go-swagger-generator/src/swagger"
 )
 
@@ -55,6 +56,16 @@ func main() {
 
 	setupRoutes(router)
 
+	// Report routes and documented operations that drifted apart
+	if err := reconcile.Gin(router.Routes(), doc.Build()).Err(); err != nil {
+		fmt.Println(err)
+	}
+
+	// Report problems that would make the served document invalid
+	if problems := doc.Validate(); len(problems) > 0 {
+		fmt.Println(problems)
+	}
+
 	fmt.Println("Server running on http://localhost:8080")
 	fmt.Println("SwaggerGin UI available at http://localhost:8080/")
 	fmt.Println("SwaggerGin JSON available at http://localhost:8080/openapi.json")
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/middleware"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec/mime"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+	"github.com/ruiborda/go-swagger-generator/src/swaggin"
+)
+
+type UserDto struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func main() {
+	router := gin.Default()
+
+	router.Use(middleware.SwaggerGin(middleware.DefaultSwaggerConfig()))
+
+	swagger.Swagger().
+		Info(func(info openapi.Info) {
+			info.Title("Wrapped Router Api").
+				Version("1.0").
+				Description("Routes registered and documented in a single call.")
+		}).
+		BasePath("/v1")
+
+	api := swaggin.Wrap(router).Group("/v1")
+	users := api.Group("/users")
+
+	users.GET("/:id", func(op openapi.Operation) {
+		op.Summary("Find user by ID").
+			Tag("users").
+			Produce(mime.ApplicationJSON).
+			PathParameter("id", func(p openapi.Parameter) {
+				p.Type("integer").Format("int64")
+			}).
+			Response(http.StatusOK, func(r openapi.Response) {
+				r.Description("successful operation").SchemaFromDTO(&UserDto{})
+			})
+	}, GetUserById)
+
+	users.POST("", func(op openapi.Operation) {
+		op.Summary("Create user").
+			Tag("users").
+			Consume(mime.ApplicationJSON).
+			Produce(mime.ApplicationJSON).
+			BodyParameter(func(p openapi.Parameter) {
+				p.Required(true).SchemaFromDTO(&UserDto{})
+			}).
+			Response(http.StatusCreated, func(r openapi.Response) {
+				r.Description("user created").SchemaFromDTO(&UserDto{})
+			})
+	}, CreateUser)
+
+	fmt.Println("Server running on http://localhost:8080")
+	_ = router.Run(":8080")
+}
+
+func GetUserById(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "name": "John Doe"})
+}
+
+func CreateUser(c *gin.Context) {
+	var user UserDto
+	if err := c.ShouldBindJSON(&user); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
@@ -0,0 +1,35 @@
+// Command doccomments extracts the Go doc comments of types, struct fields
+// and functions into a JSON file that can be embedded and passed to
+// SwaggerDoc.DocComments. It is meant to be run from go generate:
+//
+//	//go:generate go run github.com/ruiborda/go-swagger-generator/src/cmd/doccomments -o doccomments.json ./...
+//
+// The arguments are go list package patterns and default to ".".
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ruiborda/go-swagger-generator/src/doccomments"
+)
+
+func main() {
+	output := flag.String("o", "doccomments.json", "output file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: doccomments [-o file] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	comments, err := doccomments.Extract(flag.Args()...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "doccomments:", err)
+		os.Exit(1)
+	}
+	if err := comments.WriteFile(*output); err != nil {
+		fmt.Fprintln(os.Stderr, "doccomments:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,133 @@
+// Command specgen writes the document registered by a set of packages to a
+// file, without starting the server. It builds a temporary program that
+// imports the packages, so their package-level
+// "var _ = swagger.Swagger()..." declarations run, and then calls
+// swagger.WriteFile:
+//
+//	go run github.com/ruiborda/go-swagger-generator/src/cmd/specgen -o openapi.yaml ./controller/...
+//
+// Only importable packages can be loaded: documentation registered inside
+// main() or in package main is not seen. The format is chosen from the output
+// extension unless -format is given.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var programTemplate = template.Must(template.New("main").Parse(`// Code generated by specgen. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+{{range .}}	_ "{{.}}"
+{{end}})
+
+func main() {
+	if err := swagger.WriteFile(os.Args[1], swagger.Format(os.Args[2])); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+func main() {
+	output := flag.String("o", "openapi.json", "output file")
+	format := flag.String("format", "", "json or yaml, chosen from the output extension when empty")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: specgen [-o file] [-format json|yaml] packages\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*output, *format, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "specgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(output, format string, patterns []string) error {
+	packages, err := importablePackages(patterns)
+	if err != nil {
+		return err
+	}
+	moduleDir, err := goCommand("list", "-m", "-f", "{{.Dir}}")
+	if err != nil {
+		return err
+	}
+	output, err = filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+
+	// The program must live inside the module to import its packages; the
+	// leading dot keeps it out of "./..." patterns.
+	dir, err := os.MkdirTemp(strings.TrimSpace(moduleDir), ".specgen-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	var program bytes.Buffer
+	if err := programTemplate.Execute(&program, packages); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), program.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "run", dir, output, format)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// importablePackages resolves patterns to import paths, skipping main packages.
+func importablePackages(patterns []string) ([]string, error) {
+	args := append([]string{"list", "-f", "{{.Name}} {{.ImportPath}}"}, patterns...)
+	out, err := goCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	var packages []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		name, importPath, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		if name == "main" {
+			fmt.Fprintf(os.Stderr, "specgen: skipping command %s, it cannot be imported\n", importPath)
+			continue
+		}
+		packages = append(packages, importPath)
+	}
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no importable packages match %s", strings.Join(patterns, " "))
+	}
+	return packages, nil
+}
+
+func goCommand(args ...string) (string, error) {
+	cmd := exec.Command("go", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("go %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,114 @@
+// Package doccomments extracts Go doc comments of types, struct fields and
+// functions so they can be used as descriptions in the generated document.
+//
+// Comments are usually extracted at build time with go generate and embedded
+// into the binary, so the source files are not needed at runtime:
+//
+//	//go:generate go run github.com/ruiborda/go-swagger-generator/src/cmd/doccomments -o doccomments.json ./...
+//
+//	//go:embed doccomments.json
+//	var docComments []byte
+//
+//	swagger.Swagger().DocComments(doccomments.MustLoad(docComments))
+package doccomments
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Comments holds the doc comments of a set of packages. Types and Funcs are
+// keyed by import path and name, as in "example.com/app/dto.Pet" and
+// "example.com/app/handlers.GetPet"; methods are keyed by their receiver
+// type, as in "example.com/app/handlers.PetHandler.Get".
+type Comments struct {
+	Types map[string]Type   `json:"types,omitempty"`
+	Funcs map[string]string `json:"funcs,omitempty"`
+}
+
+// Type holds the doc comment of a type and of its struct fields, keyed by Go
+// field name.
+type Type struct {
+	Doc    string            `json:"doc,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// Load decodes comments written by Comments.JSON.
+func Load(data []byte) (Comments, error) {
+	var comments Comments
+	err := json.Unmarshal(data, &comments)
+	return comments, err
+}
+
+// MustLoad is like Load but panics on malformed data, for embedded artifacts.
+func MustLoad(data []byte) Comments {
+	comments, err := Load(data)
+	if err != nil {
+		panic("doccomments: " + err.Error())
+	}
+	return comments
+}
+
+// JSON encodes the comments as the artifact read by Load.
+func (c Comments) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// WriteFile writes the comments as JSON to path.
+func (c Comments) WriteFile(path string) error {
+	data, err := c.JSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Merge adds the comments of other, which take precedence on conflicts.
+func (c *Comments) Merge(other Comments) {
+	for key, value := range other.Types {
+		if c.Types == nil {
+			c.Types = make(map[string]Type)
+		}
+		c.Types[key] = value
+	}
+	for key, value := range other.Funcs {
+		if c.Funcs == nil {
+			c.Funcs = make(map[string]string)
+		}
+		c.Funcs[key] = value
+	}
+}
+
+// Type returns the comments of the named type of package pkgPath.
+func (c Comments) Type(pkgPath, name string) (Type, bool) {
+	t, ok := c.Types[pkgPath+"."+name]
+	return t, ok
+}
+
+// Func returns the doc comment of a function from the name reported by
+// runtime.FuncForPC, such as "example.com/app/handlers.(*PetHandler).Get-fm".
+func (c Comments) Func(runtimeName string) (string, bool) {
+	name := strings.TrimSuffix(runtimeName, "-fm")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	doc, ok := c.Funcs[name]
+	return doc, ok
+}
+
+// Title returns the first sentence of a doc comment, on a single line. As in
+// godoc, a sentence ends at a period followed by a space that does not follow
+// a single upper case letter, so initials like "J. Smith" are kept together.
+func Title(doc string) string {
+	doc = strings.Join(strings.Fields(doc), " ")
+	for i := 1; i+1 < len(doc); i++ {
+		if doc[i] != '.' || doc[i+1] != ' ' {
+			continue
+		}
+		initial := unicode.IsUpper(rune(doc[i-1])) && (i == 1 || doc[i-2] == ' ')
+		if !initial {
+			return doc[:i+1]
+		}
+	}
+	return doc
+}
@@ -0,0 +1,159 @@
+package doccomments
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Extract collects the doc comments of the packages matched by the given
+// go list patterns, e.g. "./..." or "example.com/app/dto". It runs the go
+// command, so it needs the module sources and a Go toolchain.
+func Extract(patterns ...string) (Comments, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	args := append([]string{"list", "-e", "-f", "{{.ImportPath}}\t{{.Dir}}"}, patterns...)
+	cmd := exec.Command("go", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return Comments{}, fmt.Errorf("go list %s: %w: %s", strings.Join(patterns, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	var comments Comments
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		importPath, dir, ok := strings.Cut(line, "\t")
+		if !ok || dir == "" {
+			continue
+		}
+		pkg, err := ParseDir(dir, importPath)
+		if err != nil {
+			return Comments{}, err
+		}
+		comments.Merge(pkg)
+	}
+	return comments, nil
+}
+
+// ParseDir collects the doc comments of the Go files in dir that belong to
+// the package for the current build context, excluding tests and files left
+// out by build constraints such as "//go:build ignore", keyed with
+// importPath. Files that fail to parse are skipped.
+func ParseDir(dir, importPath string) (Comments, error) {
+	comments := Comments{Types: make(map[string]Type), Funcs: make(map[string]string)}
+	pkg, err := build.ImportDir(dir, 0)
+	var noGo *build.NoGoError
+	if errors.As(err, &noGo) {
+		return comments, nil
+	}
+	files := append(append([]string(nil), pkg.GoFiles...), pkg.CgoFiles...)
+	if err != nil && len(files) == 0 {
+		return Comments{}, err
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		collectFile(&comments, file, importPath)
+	}
+	return comments, nil
+}
+
+func collectFile(comments *Comments, file *ast.File, importPath string) {
+	if file.Name.Name == "main" {
+		importPath = "main" // reflect reports "main" as the package path of commands
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && len(decl.Specs) == 1 {
+					doc = decl.Doc
+				}
+				t := Type{Doc: text(doc)}
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+					t.Fields = fieldComments(structType)
+				}
+				if t.Doc != "" || len(t.Fields) > 0 {
+					comments.Types[importPath+"."+typeSpec.Name.Name] = t
+				}
+			}
+		case *ast.FuncDecl:
+			doc := text(decl.Doc)
+			if doc == "" {
+				continue
+			}
+			name := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				name = receiverName(decl.Recv.List[0].Type) + "." + name
+			}
+			comments.Funcs[importPath+"."+name] = doc
+		}
+	}
+}
+
+// fieldComments returns the doc comment, or else the line comment, of each
+// named field.
+func fieldComments(structType *ast.StructType) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range structType.Fields.List {
+		doc := text(field.Doc)
+		if doc == "" {
+			doc = text(field.Comment)
+		}
+		if doc == "" {
+			continue
+		}
+		for _, name := range field.Names {
+			fields[name.Name] = doc
+		}
+		if len(field.Names) == 0 { // embedded field, named after its type
+			fields[receiverName(field.Type)] = doc
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// receiverName returns the type name of a receiver or embedded field,
+// dropping pointers, package qualifiers and type parameters.
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+func text(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.TrimSpace(group.Text())
+}
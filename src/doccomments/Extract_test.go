@@ -0,0 +1,64 @@
+package doccomments
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const petsPath = "github.com/ruiborda/go-swagger-generator/src/doccomments/testdata/pets"
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestParseDir(t *testing.T) {
+	comments, err := ParseDir("testdata/pets", petsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTypes := map[string]Type{
+		petsPath + ".Pet": {
+			Doc:    "Pet is a pet in the store.",
+			Fields: map[string]string{"Name": "Name is the pet's name.", "Tag": "Tag labels the pet."},
+		},
+	}
+	if !reflect.DeepEqual(comments.Types, wantTypes) {
+		t.Errorf("types %+v, want %+v", comments.Types, wantTypes)
+	}
+	wantFuncs := []string{petsPath + ".NewPet", petsPath + ".Pet.Rename"}
+	if got := sortedKeys(comments.Funcs); !reflect.DeepEqual(got, wantFuncs) {
+		t.Errorf("funcs %q, want %q", got, wantFuncs)
+	}
+}
+
+func TestParseDirWithoutFiles(t *testing.T) {
+	comments, err := ParseDir("testdata/tools", "example.com/tools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments.Types) > 0 || len(comments.Funcs) > 0 {
+		t.Errorf("comments %+v, want none", comments)
+	}
+	if _, err := ParseDir("testdata/missing", "example.com/missing"); err == nil {
+		t.Error("missing directory: want an error")
+	}
+}
+
+func TestExtract(t *testing.T) {
+	comments, err := Extract("./testdata/pets", "./testdata/tools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sortedKeys(comments.Types); !reflect.DeepEqual(got, []string{petsPath + ".Pet"}) {
+		t.Errorf("types %q, want the Pet type only", got)
+	}
+	if doc, ok := comments.Func(petsPath + ".NewPet"); !ok || doc != "NewPet returns a pet called name." {
+		t.Errorf("NewPet doc %q, %v", doc, ok)
+	}
+}
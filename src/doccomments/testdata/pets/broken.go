@@ -0,0 +1,4 @@
+package pets
+
+// Broken does not parse.
+func Broken() {
@@ -0,0 +1,6 @@
+//go:build ignore
+
+package main
+
+// Generator is excluded by its build constraint.
+type Generator struct{}
@@ -0,0 +1,6 @@
+//go:build doccomments_never
+
+package pets
+
+// Hidden is excluded unless the doccomments_never tag is set.
+type Hidden struct{}
@@ -0,0 +1,15 @@
+// Package pets is parsed by the doccomments tests.
+package pets
+
+// Pet is a pet in the store.
+type Pet struct {
+	// Name is the pet's name.
+	Name string
+	Tag  string // Tag labels the pet.
+}
+
+// Rename changes the name of the pet.
+func (p *Pet) Rename(name string) { p.Name = name }
+
+// NewPet returns a pet called name.
+func NewPet(name string) *Pet { return &Pet{Name: name} }
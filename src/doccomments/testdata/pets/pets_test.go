@@ -0,0 +1,4 @@
+package pets
+
+// Fixture is only used by tests.
+type Fixture struct{}
@@ -0,0 +1,4 @@
+//go:build tools
+
+// Package tools only holds build constrained files.
+package tools
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+)
+
+// swaggerHandler serves the JSON and YAML documents, the documentation pages
+// and the embedded Swagger UI files of a SwaggerConfig.
+type swaggerHandler struct {
+	cfg           SwaggerConfig
+	specYAML      []byte
+	assetsURL     string
+	assetsHandler http.Handler
+	pages         map[string]Renderer
+	page          RenderPage
+}
+
+func newSwaggerHandler(config []SwaggerConfig) *swaggerHandler {
+	// Use default config if none provided
+	cfg := DefaultSwaggerConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+
+	h := &swaggerHandler{cfg: cfg}
+	if cfg.Spec != nil && cfg.YAMLPath != "" {
+		var err error
+		if h.specYAML, err = openapi_spec.ToYAML(json.RawMessage(cfg.Spec)); err != nil {
+			panic("swagger: invalid precomputed Spec: " + err.Error())
+		}
+	}
+
+	h.assetsURL, h.assetsHandler = cfg.assets()
+
+	h.pages = make(map[string]Renderer, len(cfg.Renderers)+1)
+	for path, renderer := range cfg.Renderers {
+		h.pages[path] = renderer
+	}
+	if cfg.UIPath != "" {
+		renderer := cfg.Renderer
+		if renderer == nil {
+			renderer = SwaggerUI{Options: cfg.SwaggerUI}
+		}
+		h.pages[cfg.UIPath] = renderer
+	}
+	h.page = RenderPage{SpecURL: cfg.JSONPath, AssetsURL: h.assetsURL}
+	return h
+}
+
+// SwaggerHandler returns an http.Handler serving the documents and the
+// documentation UI, for routers other than gin. Requests for other paths get
+// a 404, so it is usually mounted on the paths it serves:
+//
+//	docs := middleware.SwaggerHandler(cfg)
+//	mux.Handle("GET /openapi.json", docs)
+//	mux.Handle("GET /docs", docs)
+func SwaggerHandler(config ...SwaggerConfig) http.Handler {
+	h := newSwaggerHandler(config)
+	if h == nil {
+		return http.NotFoundHandler()
+	}
+	return h
+}
+
+// SwaggerMiddleware returns a net/http middleware serving the documents and
+// the documentation UI and passing every other request to next.
+func SwaggerMiddleware(config ...SwaggerConfig) func(next http.Handler) http.Handler {
+	h := newSwaggerHandler(config)
+	return func(next http.Handler) http.Handler {
+		if h == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if served, _ := h.serve(w, r); !served {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+func (h *swaggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if served, _ := h.serve(w, r); !served {
+		http.NotFound(w, r)
+	}
+}
+
+// serve answers r when its path is one of the configured paths and reports
+// whether it did. The error is the one already answered with a 500.
+func (h *swaggerHandler) serve(w http.ResponseWriter, r *http.Request) (bool, error) {
+	cfg := h.cfg
+	reqPath := r.URL.Path
+
+	// Check if the request is for the JSON document
+	if reqPath == cfg.JSONPath {
+		data := cfg.Spec
+		if data == nil {
+			var err error
+			if data, err = json.Marshal(swagger.BuildSpec(cfg.doc())); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return true, err
+			}
+		}
+		noCache(w)
+		write(w, "application/json; charset=utf-8", data)
+		return true, nil
+	}
+
+	// Check if the request is for the YAML document
+	if cfg.YAMLPath != "" && reqPath == cfg.YAMLPath {
+		data := h.specYAML
+		if data == nil {
+			var err error
+			if data, err = openapi_spec.ToYAML(swagger.BuildSpec(cfg.doc())); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return true, err
+			}
+		}
+		noCache(w)
+		write(w, "application/yaml; charset=utf-8", data)
+		return true, nil
+	}
+
+	// Check if the request is for an embedded Swagger UI file
+	if h.assetsHandler != nil && strings.HasPrefix(reqPath, h.assetsURL+"/") {
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		h.assetsHandler.ServeHTTP(w, r)
+		return true, nil
+	}
+
+	// Check if the request is for a documentation page
+	if renderer, ok := h.pages[reqPath]; ok {
+		var html bytes.Buffer
+		if err := renderer.Render(&html, h.page); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return true, err
+		}
+		write(w, "text/html; charset=utf-8", html.Bytes())
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func noCache(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+}
+
+func write(w http.ResponseWriter, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"html/template"
+	"io"
+)
+
+// Renderer renders the HTML page of a documentation UI for the document
+// served at page.SpecURL.
+type Renderer interface {
+	Render(w io.Writer, page RenderPage) error
+}
+
+// RenderPage holds what the middleware knows about the page being rendered
+type RenderPage struct {
+	// SpecURL is the URL of the JSON document
+	SpecURL string
+	// AssetsURL is where the Swagger UI files are loaded from, either the
+	// embedded files or a CDN
+	AssetsURL string
+}
+
+// ReDoc renders the ReDoc three-panel reference
+type ReDoc struct {
+	// Title is the page title, empty uses "ReDoc"
+	Title string
+	// ScriptURL is the ReDoc standalone bundle, empty uses ReDocScriptURL
+	ScriptURL string
+}
+
+// RapiDoc renders the RapiDoc web component
+type RapiDoc struct {
+	// Title is the page title, empty uses "RapiDoc"
+	Title string
+	// ScriptURL is the RapiDoc module, empty uses RapiDocScriptURL
+	ScriptURL string
+	// Theme is "light" or "dark", empty uses RapiDoc's default
+	Theme string
+}
+
+// Scalar renders the Scalar API reference
+type Scalar struct {
+	// Title is the page title, empty uses "API Reference"
+	Title string
+	// ScriptURL is the Scalar bundle, empty uses ScalarScriptURL
+	ScriptURL string
+}
+
+// Default CDN locations of the renderers that are not embedded
+const (
+	ReDocScriptURL   = "https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js"
+	RapiDocScriptURL = "https://unpkg.com/rapidoc@9.3.4/dist/rapidoc-min.js"
+	ScalarScriptURL  = "https://cdn.jsdelivr.net/npm/@scalar/api-reference@1.25.11"
+)
+
+func (r ReDoc) Render(w io.Writer, page RenderPage) error {
+	return redocTemplate.Execute(w, struct {
+		Title     string
+		ScriptURL string
+		RenderPage
+	}{orDefault(r.Title, "ReDoc"), orDefault(r.ScriptURL, ReDocScriptURL), page})
+}
+
+func (r RapiDoc) Render(w io.Writer, page RenderPage) error {
+	return rapiDocTemplate.Execute(w, struct {
+		Title     string
+		ScriptURL string
+		Theme     string
+		RenderPage
+	}{orDefault(r.Title, "RapiDoc"), orDefault(r.ScriptURL, RapiDocScriptURL), r.Theme, page})
+}
+
+func (r Scalar) Render(w io.Writer, page RenderPage) error {
+	return scalarTemplate.Execute(w, struct {
+		Title     string
+		ScriptURL string
+		RenderPage
+	}{orDefault(r.Title, "API Reference"), orDefault(r.ScriptURL, ScalarScriptURL), page})
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+var redocTemplate = template.Must(template.New("redoc").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1" />
+    <title>{{.Title}}</title>
+<style>body{margin:0;padding:0;}</style>
+</head>
+<body>
+<redoc spec-url="{{.SpecURL}}"></redoc>
+<script src="{{.ScriptURL}}" crossorigin></script>
+</body>
+</html>`))
+
+var rapiDocTemplate = template.Must(template.New("rapidoc").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1" />
+    <title>{{.Title}}</title>
+    <script type="module" src="{{.ScriptURL}}" crossorigin></script>
+</head>
+<body>
+<rapi-doc spec-url="{{.SpecURL}}"{{with .Theme}} theme="{{.}}"{{end}}></rapi-doc>
+</body>
+</html>`))
+
+var scalarTemplate = template.Must(template.New("scalar").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1" />
+    <title>{{.Title}}</title>
+</head>
+<body>
+<script id="api-reference" data-url="{{.SpecURL}}"></script>
+<script src="{{.ScriptURL}}" crossorigin></script>
+</body>
+</html>`))
@@ -0,0 +1,197 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+	"github.com/ruiborda/go-swagger-generator/src/validation"
+)
+
+// RequestValidationConfig holds configuration for the ValidateRequest middleware
+type RequestValidationConfig struct {
+	// Doc is the document requests are validated against, nil uses swagger.Swagger()
+	Doc openapi.SwaggerDoc
+	// OnViolation writes the response for an invalid request, nil responds
+	// 400 Bad Request with a RequestValidationError body
+	OnViolation func(c *gin.Context, violations validation.Violations)
+	// MaxMultipartMemory bounds the memory used to parse multipart forms, 0 uses 32 MB
+	MaxMultipartMemory int64
+	// MaxBodySize bounds the size of the bodies read for validation, 0 uses 10 MB
+	MaxBodySize int64
+}
+
+// RequestValidationError is the body of the default 400 response
+type RequestValidationError struct {
+	Message    string                 `json:"message"`
+	Violations []validation.Violation `json:"violations"`
+}
+
+// ValidateRequest returns a gin middleware that validates path, query, header
+// and form parameters and the JSON body of each request against the
+// documented operation of the matched route. Routes without documentation
+// are passed through untouched.
+func ValidateRequest(config ...RequestValidationConfig) gin.HandlerFunc {
+	cfg := RequestValidationConfig{}
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.OnViolation == nil {
+		cfg.OnViolation = func(c *gin.Context, violations validation.Violations) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, RequestValidationError{
+				Message:    "request validation failed",
+				Violations: violations,
+			})
+		}
+	}
+	if cfg.MaxMultipartMemory == 0 {
+		cfg.MaxMultipartMemory = 32 << 20
+	}
+	if cfg.MaxBodySize == 0 {
+		cfg.MaxBodySize = 10 << 20
+	}
+	index := &operationIndex{doc: cfg.Doc, direction: validation.Request}
+
+	return func(c *gin.Context) {
+		op, ok := index.lookup(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		var violations validation.Violations
+		pathValues := pathParameterValues(c, op.Path)
+		for _, param := range op.Parameters {
+			switch param.In {
+			case "path":
+				value, present := pathValues[param.Name]
+				violations = append(violations, index.validator.ValidateParameter(param, []string{value}, present)...)
+			case "query":
+				values, present := c.Request.URL.Query()[param.Name]
+				violations = append(violations, index.validator.ValidateParameter(param, values, present)...)
+			case "header":
+				values := c.Request.Header.Values(param.Name)
+				violations = append(violations, index.validator.ValidateParameter(param, values, len(values) > 0)...)
+			case "formData":
+				violations = append(violations, validateFormParameter(c, index.validator, param, cfg.MaxMultipartMemory)...)
+			case "body":
+				violations = append(violations, validateBody(c, index.validator, param, cfg.MaxBodySize)...)
+			}
+		}
+
+		if len(violations) > 0 {
+			cfg.OnViolation(c, violations)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func validateFormParameter(c *gin.Context, validator *validation.SchemaValidator, param openapi_spec.ParameterEntity, maxMemory int64) validation.Violations {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		_ = c.Request.ParseMultipartForm(maxMemory)
+	} else {
+		_ = c.Request.ParseForm()
+	}
+	if param.Type == "file" {
+		present := c.Request.MultipartForm != nil && len(c.Request.MultipartForm.File[param.Name]) > 0
+		return validator.ValidateParameter(param, nil, present)
+	}
+	values, present := c.Request.PostForm[param.Name]
+	return validator.ValidateParameter(param, values, present)
+}
+
+// validateBody validates JSON bodies, reading them up to maxSize bytes and
+// restoring them for the handler. Other bodies are left unread and are only
+// checked for presence.
+func validateBody(c *gin.Context, validator *validation.SchemaValidator, param openapi_spec.ParameterEntity, maxSize int64) validation.Violations {
+	if contentType := c.ContentType(); contentType != "" && !strings.Contains(contentType, "json") {
+		if param.Required && (c.Request.Body == nil || c.Request.Body == http.NoBody || c.Request.ContentLength == 0) {
+			return validation.Violations{{Location: "body", Message: "is required"}}
+		}
+		return nil
+	}
+	var data []byte
+	if c.Request.Body != nil {
+		var err error
+		data, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return validation.Violations{{Location: "body", Message: fmt.Sprintf("must not be larger than %d bytes", maxSize)}}
+		}
+		if err != nil {
+			return validation.Violations{{Location: "body", Message: "could not be read"}}
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		if param.Required {
+			return validation.Violations{{Location: "body", Message: "is required"}}
+		}
+		return nil
+	}
+	value, err := validation.DecodeJSON(data)
+	if err != nil {
+		return validation.Violations{{Location: "body", Message: "must be valid JSON"}}
+	}
+	return validator.Validate("body", param.Schema, value)
+}
+
+// pathParameterValues maps the template's parameter names to the values of
+// the matched gin route, pairing them by position since names may differ.
+func pathParameterValues(c *gin.Context, template string) map[string]string {
+	names := swagger.PathParameterNames(template)
+	var ginNames []string
+	for _, segment := range strings.Split(c.FullPath(), "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			ginNames = append(ginNames, segment[1:])
+		}
+	}
+	values := make(map[string]string, len(names))
+	for i, name := range names {
+		if i >= len(ginNames) {
+			break
+		}
+		if value, ok := c.Params.Get(ginNames[i]); ok {
+			values[name] = strings.TrimPrefix(value, "/")
+		}
+	}
+	return values
+}
+
+// operationIndex finds the documented operation of the route matched by gin.
+// The document is built on first use, once all routes have been documented.
+type operationIndex struct {
+	doc       openapi.SwaggerDoc
+	direction validation.Direction
+
+	once      sync.Once
+	built     openapi_spec.SwaggerDocEntity
+	validator *validation.SchemaValidator
+}
+
+func (i *operationIndex) lookup(c *gin.Context) (validation.Operation, bool) {
+	i.once.Do(func() {
+		doc := i.doc
+		if doc == nil {
+			doc = swagger.Swagger()
+		}
+		i.built = doc.Build()
+		i.validator = validation.NewSchemaValidator(i.built.Definitions, i.direction)
+	})
+	fullPath := c.FullPath()
+	if fullPath == "" {
+		return validation.Operation{}, false
+	}
+	template := swagger.GinPathToTemplate(fullPath, i.built.BasePath)
+	return validation.FindOperation(i.built, c.Request.Method, template)
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+)
+
+type petStatus string
+
+func (petStatus) EnumValues() []any {
+	return []any{
+		openapi.EnumValue{Value: petStatus("available"), Name: "Available"},
+		openapi.EnumValue{Value: petStatus("sold"), Name: "Sold"},
+	}
+}
+
+type petLevel int
+
+func (petLevel) EnumValues() []any {
+	return []any{petLevel(1), petLevel(2)}
+}
+
+type statusPet struct {
+	Status petStatus `json:"status"`
+	Level  petLevel  `json:"level,omitempty"`
+}
+
+func TestValidateRequestEnumValuer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	doc.Path("/pets").
+		Get(func(op openapi.Operation) {
+			op.QueryParameter("status", func(p openapi.Parameter) {
+				swagger.ParamOf[petStatus](p).Required(true)
+			})
+		}).
+		Post(func(op openapi.Operation) {
+			op.BodyParameter(func(p openapi.Parameter) {
+				swagger.BodyOf[statusPet](p).Required(true)
+			})
+		})
+
+	router := gin.New()
+	router.Use(ValidateRequest(RequestValidationConfig{Doc: doc}))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/pets", ok)
+	router.POST("/pets", ok)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"query value", http.MethodGet, "/pets?status=available", "", http.StatusNoContent},
+		{"query unknown value", http.MethodGet, "/pets?status=lost", "", http.StatusBadRequest},
+		{"body value", http.MethodPost, "/pets", `{"status":"available","level":2}`, http.StatusNoContent},
+		{"body unknown value", http.MethodPost, "/pets", `{"status":"lost"}`, http.StatusBadRequest},
+		{"body unknown number", http.MethodPost, "/pets", `{"status":"sold","level":3}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("%s %s: got %d %s, want %d", tt.method, tt.target, rec.Code, rec.Body, tt.status)
+			}
+		})
+	}
+}
+
+type animal struct {
+	Type string `json:"type"`
+	Name string `json:"name" binding:"required"`
+}
+
+type dog struct {
+	animal
+	Bark bool `json:"bark"`
+}
+
+type cat struct {
+	animal
+	Lives int `json:"lives" binding:"max=9"`
+}
+
+func TestValidateRequestPolymorphicBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	swagger.PolymorphicOf[animal](doc, "type", map[string]interface{}{
+		"Dog": dog{},
+		"Cat": cat{},
+	})
+	doc.Path("/animals").Post(func(op openapi.Operation) {
+		op.BodyParameter(func(p openapi.Parameter) {
+			swagger.BodyOf[animal](p).Required(true)
+		})
+	})
+
+	router := gin.New()
+	router.Use(ValidateRequest(RequestValidationConfig{Doc: doc}))
+	router.POST("/animals", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"dog", `{"type":"Dog","name":"Rex","bark":true}`, http.StatusNoContent},
+		{"cat", `{"type":"Cat","name":"Tom","lives":9}`, http.StatusNoContent},
+		{"cat properties", `{"type":"Cat","name":"Tom","lives":10}`, http.StatusBadRequest},
+		{"dog properties", `{"type":"Dog","name":"Rex","bark":"yes"}`, http.StatusBadRequest},
+		{"base properties", `{"type":"Dog","bark":true}`, http.StatusBadRequest},
+		{"unknown type", `{"type":"Bird","name":"Tweety"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/animals", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("got %d %s, want %d", rec.Code, rec.Body, tt.status)
+			}
+		})
+	}
+}
+
+func TestValidateRequestMaxBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	doc.Path("/pets").Post(func(op openapi.Operation) {
+		op.BodyParameter(func(p openapi.Parameter) {
+			swagger.BodyOf[statusPet](p).Required(true)
+		})
+	})
+
+	tests := []struct {
+		contentType string
+		body        string
+		status      int
+		message     string
+	}{
+		{"application/json", `{"status":"sold"}`, http.StatusNoContent, ""},
+		{"application/json", `{"status":"sold","padding":"` + strings.Repeat("x", 32) + `"}`, http.StatusBadRequest, "must not be larger than 32 bytes"},
+		{"application/octet-stream", strings.Repeat("x", 64), http.StatusNoContent, ""},
+		{"application/octet-stream", "", http.StatusBadRequest, "is required"},
+	}
+	for _, tt := range tests {
+		var handlerBody string
+		router := gin.New()
+		router.Use(ValidateRequest(RequestValidationConfig{Doc: doc, MaxBodySize: 32}))
+		router.POST("/pets", func(c *gin.Context) {
+			data, _ := io.ReadAll(c.Request.Body)
+			handlerBody = string(data)
+			c.Status(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", tt.contentType)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.message) {
+			t.Errorf("%s body of %d bytes: got %d %s, want %d %q", tt.contentType, len(tt.body), rec.Code, rec.Body, tt.status, tt.message)
+		}
+		if rec.Code == http.StatusNoContent && handlerBody != tt.body {
+			t.Errorf("%s body of %d bytes: handler read %d bytes", tt.contentType, len(tt.body), len(handlerBody))
+		}
+	}
+}
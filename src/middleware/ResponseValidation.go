@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	specmime "github.com/ruiborda/go-swagger-generator/src/openapi_spec/mime"
+	"github.com/ruiborda/go-swagger-generator/src/validation"
+)
+
+// ResponseValidationConfig holds configuration for the ValidateResponse middleware
+type ResponseValidationConfig struct {
+	// Enabled determines if responses are validated
+	Enabled bool
+	// Doc is the document responses are validated against, nil uses swagger.Swagger()
+	Doc openapi.SwaggerDoc
+	// OnViolation receives the violations of a response, nil logs them
+	OnViolation func(c *gin.Context, violations validation.Violations)
+}
+
+// DefaultResponseValidationConfig returns the default ValidateResponse configuration
+func DefaultResponseValidationConfig() ResponseValidationConfig {
+	return ResponseValidationConfig{
+		Enabled: true,
+	}
+}
+
+// TestingT is the subset of testing.TB used by FailOnViolation
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// FailOnViolation returns an OnViolation callback that fails t for every
+// response that does not match the documentation.
+func FailOnViolation(t TestingT) func(c *gin.Context, violations validation.Violations) {
+	return func(c *gin.Context, violations validation.Violations) {
+		t.Errorf("%s %s: response does not match documentation: %v", c.Request.Method, c.FullPath(), violations)
+	}
+}
+
+// ValidateResponse returns a gin middleware that buffers the response of each
+// documented route and checks it against the operation: the status code must
+// be declared in Responses, the Content-Type must be listed in Produces and a
+// JSON body must match the response schema. Meant for development and tests,
+// as buffering prevents streaming responses.
+func ValidateResponse(config ...ResponseValidationConfig) gin.HandlerFunc {
+	cfg := DefaultResponseValidationConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if !cfg.Enabled {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+	if cfg.OnViolation == nil {
+		cfg.OnViolation = func(c *gin.Context, violations validation.Violations) {
+			log.Printf("[swagger] %s %s: response does not match documentation: %v", c.Request.Method, c.FullPath(), violations)
+		}
+	}
+	index := &operationIndex{doc: cfg.Doc, direction: validation.Response}
+
+	return func(c *gin.Context) {
+		op, ok := index.lookup(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffered := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = buffered
+		completed := false
+		defer func() {
+			c.Writer = original
+			if !completed {
+				// A handler panicked: drop its partial response so the
+				// recovery middleware can write its own
+				return
+			}
+			buffered.flush()
+		}()
+		c.Next()
+		completed = true
+
+		if violations := validateResponse(index.validator, op.Operation, buffered); len(violations) > 0 {
+			cfg.OnViolation(c, violations)
+		}
+	}
+}
+
+func validateResponse(validator *validation.SchemaValidator, op *openapi_spec.OperationEntity, w *bufferedWriter) validation.Violations {
+	var violations validation.Violations
+
+	response, declared := op.Responses[strconv.Itoa(w.status)]
+	if !declared {
+		response, declared = op.Responses["default"]
+	}
+	if !declared {
+		violations = append(violations, validation.Violation{
+			Location: "status",
+			Message:  fmt.Sprintf("%d is not a documented response", w.status),
+		})
+	}
+	if w.body.Len() == 0 {
+		return violations
+	}
+
+	contentType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if len(op.Produces) > 0 && !producesContains(op.Produces, contentType) {
+		violations = append(violations, validation.Violation{
+			Location: "header.Content-Type",
+			Message:  fmt.Sprintf("%q is not listed in produces %v", contentType, op.Produces),
+		})
+	}
+
+	if declared && response.Schema != nil && strings.Contains(contentType, "json") {
+		value, err := validation.DecodeJSON(w.body.Bytes())
+		if err != nil {
+			violations = append(violations, validation.Violation{Location: "body", Message: "must be valid JSON"})
+		} else {
+			violations = append(violations, validator.Validate("body", response.Schema, value)...)
+		}
+	}
+	return violations
+}
+
+func producesContains(produces []specmime.MimeType, contentType string) bool {
+	for _, produced := range produces {
+		mediaType, _, err := mime.ParseMediaType(string(produced))
+		if err == nil && strings.EqualFold(mediaType, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds the status and body written by the handlers until
+// the response has been validated.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	status  int
+	written bool
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+// Size and Written follow gin's ResponseWriter, which reports -1 until the
+// headers are written.
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+func (w *bufferedWriter) Flush() {}
+
+// flush writes the buffered response to the underlying writer.
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+	"github.com/ruiborda/go-swagger-generator/src/validation"
+)
+
+func TestValidateResponseRecoversPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	doc.Path("/panic").Get(func(op openapi.Operation) {
+		op.Response(http.StatusOK, func(r openapi.Response) {})
+	})
+
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		c.String(http.StatusInternalServerError, "recovered")
+	}))
+	router.Use(ValidateResponse(ResponseValidationConfig{
+		Enabled:     true,
+		Doc:         doc,
+		OnViolation: func(c *gin.Context, violations validation.Violations) {},
+	}))
+	router.GET("/panic", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != "recovered" {
+		t.Errorf("got %d %q, want 500 \"recovered\"", rec.Code, rec.Body)
+	}
+}
+
+func TestValidateResponseWritten(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	doc.Path("/pets").Get(func(op openapi.Operation) {
+		op.Response(http.StatusNoContent, func(r openapi.Response) {})
+	})
+
+	var violations validation.Violations
+	router := gin.New()
+	router.Use(ValidateResponse(ResponseValidationConfig{
+		Enabled: true,
+		Doc:     doc,
+		OnViolation: func(c *gin.Context, v validation.Violations) {
+			violations = v
+		},
+	}))
+	router.GET("/pets", func(c *gin.Context) {
+		if c.Writer.Written() || c.Writer.Size() != -1 {
+			t.Errorf("before writing: Written() = %v, Size() = %d", c.Writer.Written(), c.Writer.Size())
+		}
+		c.Status(http.StatusNoContent)
+		c.Writer.WriteHeaderNow()
+		if !c.Writer.Written() || c.Writer.Status() != http.StatusNoContent || c.Writer.Size() != 0 {
+			t.Errorf("after writing: Written() = %v, Status() = %d, Size() = %d", c.Writer.Written(), c.Writer.Status(), c.Writer.Size())
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("got %d, want 204", rec.Code)
+	}
+	if len(violations) > 0 {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+}
+
+type taggedPet struct {
+	Name string            `json:"name"`
+	Tags []string          `json:"tags"`
+	Meta map[string]string `json:"meta"`
+	Age  int               `json:"age"`
+}
+
+func TestValidateResponseNilCollections(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	doc.Path("/pets").Get(func(op openapi.Operation) {
+		op.Response(http.StatusOK, func(r openapi.Response) {
+			swagger.ResponseOf[taggedPet](r)
+		})
+	})
+
+	tests := []struct {
+		name string
+		body any
+		want string
+	}{
+		{"nil slice and map", taggedPet{Name: "Rex"}, ""},
+		{"filled slice and map", taggedPet{Name: "Rex", Tags: []string{"dog"}, Meta: map[string]string{"a": "b"}}, ""},
+		{"null number", map[string]any{"name": "Rex", "tags": nil, "meta": nil, "age": nil}, "body.age: must not be null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var violations validation.Violations
+			router := gin.New()
+			router.Use(ValidateResponse(ResponseValidationConfig{
+				Enabled: true,
+				Doc:     doc,
+				OnViolation: func(c *gin.Context, v validation.Violations) {
+					violations = v
+				},
+			}))
+			router.GET("/pets", func(c *gin.Context) { c.JSON(http.StatusOK, tt.body) })
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/pets", nil))
+			if violations.Error() != tt.want {
+				t.Errorf("got violations %q, want %q", violations, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+// SwaggerUI renders Swagger UI, the default renderer
+type SwaggerUI struct {
+	Options SwaggerUIOptions
+}
+
+// SwaggerUIOptions configures the Swagger UI page and the options passed to
+// SwaggerUIBundle. Zero values keep Swagger UI's defaults.
+type SwaggerUIOptions struct {
+	// Title is the page title, empty uses "SwaggerUI"
+	Title string
+	// FaviconURL is the URL of the page icon
+	FaviconURL string
+	// CSS is a stylesheet added after Swagger UI's own, e.g. to hide the top bar
+	CSS string
+	// CSSURL is the URL of a stylesheet added after Swagger UI's own
+	CSSURL string
+
+	// DeepLinking updates the URL when tags and operations are expanded
+	DeepLinking bool
+	// PersistAuthorization keeps the authorization data across reloads
+	PersistAuthorization bool
+	// DocExpansion is "list", "full" or "none", empty uses "list"
+	DocExpansion string
+	// DefaultModelsExpandDepth is how deep the models are expanded, -1 hides
+	// the models section and nil uses 1
+	DefaultModelsExpandDepth *int
+	// Filter shows the box that filters operations by tag
+	Filter bool
+	// TryItOutEnabled opens the "Try it out" section of every operation
+	TryItOutEnabled bool
+	// SupportedSubmitMethods lists the methods "Try it out" is enabled for,
+	// e.g. []string{"get"}; nil enables it for all and an empty slice for none
+	SupportedSubmitMethods []string
+
+	// InitOAuth pre-fills the OAuth2 authorization dialog
+	InitOAuth *SwaggerUIOAuth
+	// RequestInterceptor is a JavaScript function applied to every request
+	// sent by Swagger UI, e.g. "(req) => { req.headers['X-Tenant'] = 'demo'; return req; }".
+	// It is trusted code and is written into the page as is.
+	RequestInterceptor string
+}
+
+// SwaggerUIOAuth holds the parameters of Swagger UI's initOAuth
+type SwaggerUIOAuth struct {
+	ClientID                          string   `json:"clientId"`
+	AppName                           string   `json:"appName,omitempty"`
+	Realm                             string   `json:"realm,omitempty"`
+	Scopes                            []string `json:"scopes,omitempty"`
+	UsePKCEWithAuthorizationCodeGrant bool     `json:"usePkceWithAuthorizationCodeGrant,omitempty"`
+}
+
+func (r SwaggerUI) Render(w io.Writer, page RenderPage) error {
+	options := r.Options
+	data := struct {
+		Title              string
+		FaviconURL         string
+		CSS                template.CSS
+		CSSURL             string
+		Config             map[string]interface{}
+		RequestInterceptor template.JS
+		InitOAuth          *SwaggerUIOAuth
+		RenderPage
+	}{
+		Title:      orDefault(options.Title, "SwaggerUI"),
+		FaviconURL: options.FaviconURL,
+		CSS:        template.CSS(closeTagSafe(options.CSS)),
+		CSSURL:     options.CSSURL,
+		Config:     options.config(page.SpecURL),
+		InitOAuth:  options.InitOAuth,
+		RenderPage: page,
+	}
+	if options.RequestInterceptor != "" {
+		data.RequestInterceptor = template.JS(closeTagSafe(options.RequestInterceptor))
+	}
+	return swaggerUITemplate.Execute(w, data)
+}
+
+// config returns the SwaggerUIBundle options that are set, encoded to JSON
+// by the template.
+func (o SwaggerUIOptions) config(specURL string) map[string]interface{} {
+	config := map[string]interface{}{"url": specURL}
+	if o.DeepLinking {
+		config["deepLinking"] = true
+	}
+	if o.PersistAuthorization {
+		config["persistAuthorization"] = true
+	}
+	if o.DocExpansion != "" {
+		config["docExpansion"] = o.DocExpansion
+	}
+	if o.DefaultModelsExpandDepth != nil {
+		config["defaultModelsExpandDepth"] = *o.DefaultModelsExpandDepth
+	}
+	if o.Filter {
+		config["filter"] = true
+	}
+	if o.TryItOutEnabled {
+		config["tryItOutEnabled"] = true
+	}
+	if o.SupportedSubmitMethods != nil {
+		methods := make([]string, len(o.SupportedSubmitMethods))
+		for i, method := range o.SupportedSubmitMethods {
+			methods[i] = strings.ToLower(method)
+		}
+		config["supportedSubmitMethods"] = methods
+	}
+	return config
+}
+
+// closeTagSafe escapes "</" in trusted CSS or JavaScript so it cannot close
+// the element it is written into; "<\/" means the same in both languages.
+func closeTagSafe(code string) string {
+	return strings.ReplaceAll(code, "</", `<\/`)
+}
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1" />
+    <meta name="description" content="SwaggerUI" />
+    <title>{{.Title}}</title>
+{{- with .FaviconURL}}
+    <link rel="icon" href="{{.}}" />
+{{- end}}
+    <link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css" />
+{{- with .CSSURL}}
+    <link rel="stylesheet" href="{{.}}" />
+{{- end}}
+<style>body{margin:0;padding:0;}</style>
+{{- with .CSS}}
+<style>{{.}}</style>
+{{- end}}
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{.AssetsURL}}/swagger-ui-bundle.js" crossorigin></script>
+<script src="{{.AssetsURL}}/swagger-ui-standalone-preset.js" crossorigin></script>
+<script>
+    window.onload = () => {
+        window.ui = SwaggerUIBundle(Object.assign({{.Config}}, {
+            dom_id: '#swagger-ui',
+            presets: [
+                SwaggerUIBundle.presets.apis,
+                SwaggerUIStandalonePreset
+            ],
+            layout: "StandaloneLayout",
+{{- with .RequestInterceptor}}
+            requestInterceptor: {{.}},
+{{- end}}
+        }));
+{{- with .InitOAuth}}
+        window.ui.initOAuth({{.}});
+{{- end}}
+    };
+</script>
+</body>
+</html>`))
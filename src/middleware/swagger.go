@@ -2,111 +2,104 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
 	"github.com/ruiborda/go-swagger-generator/src/swagger"
-	"html/template"
+	"github.com/ruiborda/go-swagger-generator/src/swaggerui"
 	"net/http"
+	"strings"
 )
 
-// SwaggerConfig holds configuration for the SwaggerGin middleware
+// SwaggerConfig holds configuration for the SwaggerGin middleware and SwaggerHandler
 type SwaggerConfig struct {
 	// Enabled determines if the SwaggerGin UI is enabled
 	Enabled bool
 	// JSONPath is the path where the SwaggerGin JSON will be served
 	JSONPath string
+	// YAMLPath is the path where the same document is served as YAML, empty disables it
+	YAMLPath string
 	// UIPath is the path where the SwaggerGin UI will be served
 	UIPath string
+	// Renderer renders the page served at UIPath, nil uses Swagger UI
+	// configured with SwaggerUI
+	Renderer Renderer
+	// SwaggerUI configures the default Swagger UI page
+	SwaggerUI SwaggerUIOptions
+	// Renderers mounts more documentation pages, keyed by path, all reading
+	// the document served at JSONPath, e.g. {"/redoc": middleware.ReDoc{}}
+	Renderers map[string]Renderer
+	// AssetsPath is the path prefix the embedded Swagger UI files are served
+	// under, empty uses "/swagger-ui/"
+	AssetsPath string
+	// AssetsURL loads the Swagger UI files from a custom CDN or self-hosted
+	// location instead, e.g. "https://cdn.example.com/swagger-ui-dist@5.11.0".
+	// Without it, swaggerui.CDNURL is used when the embedded files are missing
+	AssetsURL string
+	// Doc is the document to serve, nil serves the default swagger.Swagger() document
+	Doc openapi.SwaggerDoc
+	// Spec is a precomputed JSON document, e.g. written by swagger.WriteFile
+	// and embedded with //go:embed, served as is instead of building Doc
+	Spec []byte
+}
+
+func (cfg SwaggerConfig) doc() openapi.SwaggerDoc {
+	if cfg.Doc != nil {
+		return cfg.Doc
+	}
+	return swagger.Swagger()
 }
 
 // DefaultSwaggerConfig returns the default SwaggerGin configuration
 func DefaultSwaggerConfig() SwaggerConfig {
 	return SwaggerConfig{
-		Enabled:  true,
-		JSONPath: "/openapi.json",
-		UIPath:   "/",
+		Enabled:    true,
+		JSONPath:   "/openapi.json",
+		YAMLPath:   "/openapi.yaml",
+		UIPath:     "/",
+		AssetsPath: "/swagger-ui/",
 	}
 }
 
-// SwaggerGin returns a gin middleware for serving SwaggerGin UI and JSON
-func SwaggerGin(config ...SwaggerConfig) gin.HandlerFunc {
-	// Use default config if none provided
-	cfg := DefaultSwaggerConfig()
-	if len(config) > 0 {
-		cfg = config[0]
+// assets returns the URL the page loads the Swagger UI files from and the
+// handler serving the embedded files, nil when they are loaded elsewhere.
+func (cfg SwaggerConfig) assets() (string, http.Handler) {
+	if cfg.AssetsURL != "" {
+		return strings.TrimSuffix(cfg.AssetsURL, "/"), nil
+	}
+	if !swaggerui.Available() {
+		return swaggerui.CDNURL, nil
 	}
+	prefix := cfg.AssetsPath
+	if prefix == "" {
+		prefix = "/swagger-ui/"
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return strings.TrimSuffix(prefix, "/"), http.StripPrefix(prefix, http.FileServer(http.FS(swaggerui.Assets())))
+}
+
+// SwaggerGin returns a gin middleware for serving SwaggerGin UI and JSON. It
+// adapts SwaggerHandler, passing the requests it does not serve to the next
+// handlers.
+func SwaggerGin(config ...SwaggerConfig) gin.HandlerFunc {
+	h := newSwaggerHandler(config)
 
 	// If disabled, return an empty middleware
-	if !cfg.Enabled {
+	if h == nil {
 		return func(c *gin.Context) {
 			c.Next()
 		}
 	}
 
-	// HTML template for SwaggerGin UI
-	swaggerTemplate := template.Must(template.New("swagger").Parse(`<!DOCTYPE html>
-<html lang="en">
-<head>
-    <meta charset="utf-8" />
-    <meta name="viewport" content="width=device-width, initial-scale=1" />
-    <meta name="description" content="SwaggerUI" />
-    <title>{{.Title}}</title>
-    <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.11.0/swagger-ui.css" />
-<style>body{margin:0;padding:0;}</style>
-</head>
-<body>
-<div id="swagger-ui"></div>
-<script src="https://unpkg.com/swagger-ui-dist@5.11.0/swagger-ui-bundle.js" crossorigin></script>
-<script src="https://unpkg.com/swagger-ui-dist@5.11.0/swagger-ui-standalone-preset.js" crossorigin></script>
-<script>
-    window.onload = () => {
-        window.ui = SwaggerUIBundle({
-            url: '{{.JSONPath}}',
-            dom_id: '#swagger-ui',
-            presets: [
-                SwaggerUIBundle.presets.apis,
-                SwaggerUIStandalonePreset
-            ],
-            layout: "StandaloneLayout",
-        });
-    };
-</script>
-</body>
-</html>`))
-
 	return func(c *gin.Context) {
-		// Skip this middleware if the route doesn't match
-		reqPath := c.Request.URL.Path
-
-		// Check if the request is for the SwaggerGin JSON
-		if reqPath == cfg.JSONPath {
-			c.Header("Content-Type", "application/json")
-			c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
-			c.Header("Pragma", "no-cache")
-			c.Header("Expires", "0")
-			c.JSON(http.StatusOK, swagger.Swagger().Build())
-			c.Abort()
-			return
+		served, err := h.serve(c.Writer, c.Request)
+		if err != nil {
+			_ = c.Error(err)
 		}
-
-		// Check if the request is for the SwaggerGin UI
-		if reqPath == cfg.UIPath {
-			data := struct {
-				Title    string
-				JSONPath string
-			}{
-				Title:    "SwaggerUI",
-				JSONPath: cfg.JSONPath,
-			}
-
-			c.Header("Content-Type", "text/html")
-			if err := swaggerTemplate.Execute(c.Writer, data); err != nil {
-				_ = c.Error(err)
-				c.AbortWithStatus(http.StatusInternalServerError)
-				return
-			}
+		if served {
 			c.Abort()
 			return
 		}
-
 		c.Next()
 	}
 }
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/swaggerui"
+)
+
+func TestSwaggerGinDefaultConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(SwaggerGin(DefaultSwaggerConfig()))
+
+	assetsURL := swaggerui.CDNURL
+	if swaggerui.Available() {
+		assetsURL = "/swagger-ui"
+	}
+	tests := []struct {
+		path        string
+		contentType string
+		contains    string
+	}{
+		{"/", "text/html; charset=utf-8", `href="` + assetsURL + `/swagger-ui.css"`},
+		{"/openapi.json", "application/json; charset=utf-8", `"swagger":"2.0"`},
+		{"/openapi.yaml", "application/yaml; charset=utf-8", "swagger: \"2.0\""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("GET %s: got %d %s, want 200 %s", tt.path, rec.Code, rec.Header().Get("Content-Type"), tt.contentType)
+		}
+		if !strings.Contains(rec.Body.String(), tt.contains) {
+			t.Errorf("GET %s: body does not contain %s:\n%s", tt.path, tt.contains, rec.Body)
+		}
+	}
+}
+
+func TestSwaggerHandlerDefaultConfig(t *testing.T) {
+	handler := SwaggerHandler()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "swagger-ui-bundle.js") {
+		t.Errorf("GET /: got %d %s", rec.Code, rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /missing: got %d, want 404", rec.Code)
+	}
+}
@@ -24,5 +24,6 @@ type Operation interface {
 	Security(schemeName string, scopes ...string) Operation
 	Deprecated(deprecated bool) Operation
 	ExternalDocumentation(url string, description string) Operation
+	Handler(handler interface{}) Operation
 	Path() PathItem
 }
@@ -1,6 +1,8 @@
 package openapi
 
 import (
+	"reflect"
+
 	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
 )
 
@@ -9,6 +11,7 @@ type Parameter interface {
 	Required(required bool) Parameter
 	Schema(s openapi_spec.SchemaEntity) Parameter
 	SchemaFromDTO(dto interface{}) Parameter
+	SchemaFromType(t reflect.Type) Parameter
 	Type(paramType string) Parameter
 	Format(format string) Parameter
 	AllowEmptyValue(allow bool) Parameter
@@ -24,5 +27,6 @@ type Parameter interface {
 	MinItems(min int) Parameter
 	UniqueItems(unique bool) Parameter
 	Enum(values ...interface{}) Parameter
+	TypeOf(t reflect.Type) Parameter
 	MultipleOf(val float64) Parameter
 }
@@ -1,6 +1,8 @@
 package openapi
 
 import (
+	"reflect"
+
 	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
 )
 
@@ -8,6 +10,7 @@ type Response interface {
 	Description(description string) Response
 	Schema(s openapi_spec.SchemaEntity) Response
 	SchemaFromDTO(dto interface{}) Response
+	SchemaFromType(t reflect.Type) Response
 	SchemaRef(ref string) Response
 	Header(name string, config func(Header)) Response
 	Example(mimeType string, exampleValue interface{}) Response
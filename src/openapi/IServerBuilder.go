@@ -2,4 +2,5 @@ package openapi
 
 type Server interface {
 	Description(description string) Server
+	Variable(name string, defaultValue string, description string, enum ...string) Server
 }
@@ -21,3 +21,26 @@ type SwaggerDoc interface {
 	ExternalDocumentation(url string, description string) SwaggerDoc
 	Build() entity2.SwaggerDocEntity
 }
+
+// CollisionStrategy decides what happens when Go types of different packages
+// get the same definition name, e.g. two DTOs named Tag.
+type CollisionStrategy int
+
+const (
+	// CollisionError reports the collision as a builder error, so
+	// BuildChecked fails and strict mode panics. Build still qualifies the
+	// names like CollisionQualify, so the document stays correct.
+	CollisionError CollisionStrategy = iota
+	// CollisionQualify silently gives every type sharing the name a
+	// package-qualified one such as "dto.Tag", adding parent packages until
+	// they are unique.
+	CollisionQualify
+)
+
+// EnumValue is a value of a Go enum type, with the name of its constant and
+// a description for code generators.
+type EnumValue struct {
+	Value       interface{}
+	Name        string
+	Description string
+}
@@ -0,0 +1,92 @@
+package openapi3_spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the converter tests")
+
+// adaptedDoc holds the schemas whose keywords differ between OpenAPI 3.0 and
+// 3.1: nullability, examples, single valued enums, exclusive bounds and $ref
+// siblings.
+func adaptedDoc() openapi_spec.SwaggerDocEntity {
+	ten, zero := 10.0, 0.0
+	return openapi_spec.SwaggerDocEntity{
+		Swagger: "2.0",
+		Info:    openapi_spec.InfoEntity{Title: "Adapt", Version: "1.0"},
+		Paths: map[string]openapi_spec.PathItemEntity{
+			"/pets": {Get: &openapi_spec.OperationEntity{
+				Parameters: []openapi_spec.ParameterEntity{
+					{Name: "limit", In: "query", Type: "integer", Maximum: &ten, ExclusiveMaximum: true, Minimum: &zero},
+					{Name: "kind", In: "query", Type: "string", Enum: []interface{}{"dog"}},
+				},
+				Responses: map[string]openapi_spec.ResponseEntity{
+					"200": {
+						Description: "OK",
+						Schema:      &openapi_spec.SchemaEntity{Type: "array", Items: &openapi_spec.SchemaEntity{Ref: "#/definitions/Pet"}},
+						Headers: map[string]openapi_spec.HeaderEntity{
+							"X-Rate-Limit": {Type: "integer", Minimum: &zero, ExclusiveMinimum: true},
+						},
+					},
+				},
+			}},
+		},
+		Definitions: map[string]openapi_spec.SchemaEntity{
+			"Pet": {
+				Type: "object",
+				Properties: map[string]*openapi_spec.SchemaEntity{
+					"name":     {Type: "string", Example: "Rex"},
+					"nickname": {Type: "string", Nullable: true},
+					"status":   {Type: "string", Enum: []interface{}{"available", "sold"}, Nullable: true},
+					"kind":     {Type: "string", Enum: []interface{}{"dog"}},
+					"weight":   {Type: "number", Maximum: &ten, ExclusiveMaximum: true, Minimum: &zero, ExclusiveMinimum: true},
+					"owner":    {Ref: "#/definitions/Owner"},
+					"vet":      {Ref: "#/definitions/Owner", Description: "Veterinarian"},
+					"sitter":   {Ref: "#/definitions/Owner"},
+					"walker":   {Ref: "#/definitions/Owner", Description: "Dog walker"},
+				},
+				NullableProperties: map[string]bool{"sitter": true, "walker": true},
+			},
+			"Owner": {Type: "object", Properties: map[string]*openapi_spec.SchemaEntity{"name": {Type: "string"}}},
+		},
+	}
+}
+
+func TestConverterGolden(t *testing.T) {
+	tests := []struct {
+		golden  string
+		convert func(openapi_spec.SwaggerDocEntity) OpenAPIEntity
+	}{
+		{"adapt.3.0.json", FromSwagger2},
+		{"adapt.3.1.json", FromSwagger2V31},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			got, err := json.MarshalIndent(tt.convert(adaptedDoc()), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s differs from the converted document, run go test -update if the change is intended:\n%s", path, got)
+			}
+		})
+	}
+}
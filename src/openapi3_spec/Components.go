@@ -0,0 +1,10 @@
+package openapi3_spec
+
+type ComponentsEntity struct {
+	Schemas         map[string]*SchemaEntity        `json:"schemas,omitempty"`
+	Responses       map[string]ResponseEntity       `json:"responses,omitempty"`
+	Parameters      map[string]ParameterEntity      `json:"parameters,omitempty"`
+	RequestBodies   map[string]RequestBodyEntity    `json:"requestBodies,omitempty"`
+	Headers         map[string]HeaderEntity         `json:"headers,omitempty"`
+	SecuritySchemes map[string]SecuritySchemeEntity `json:"securitySchemes,omitempty"`
+}
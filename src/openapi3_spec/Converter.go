@@ -0,0 +1,590 @@
+package openapi3_spec
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec/mime"
+)
+
+const (
+	DefaultVersion   = "3.0.3"
+	DefaultVersion31 = "3.1.0"
+
+	swagger2RefPrefix = "#/definitions/"
+	schemasRefPrefix  = "#/components/schemas/"
+)
+
+// FromSwagger2 converts a Swagger 2.0 document into an OpenAPI 3.0 document.
+// Definitions become components/schemas, body and formData parameters become
+// request bodies and response schemas are expanded into content maps using
+// the operation's produces list.
+func FromSwagger2(doc openapi_spec.SwaggerDocEntity) OpenAPIEntity {
+	c := &converter{}
+	return c.convert(doc)
+}
+
+// FromSwagger2V31 converts a Swagger 2.0 document into an OpenAPI 3.1
+// document. Schemas follow JSON Schema 2020-12: nullable values are written as
+// type unions, examples as arrays, single valued enums as const and $ref keeps
+// its sibling keywords. Webhooks registered on the builder are included.
+func FromSwagger2V31(doc openapi_spec.SwaggerDocEntity) OpenAPIEntity {
+	c := &converter{v31: true}
+	return c.convert(doc)
+}
+
+type converter struct {
+	v31 bool
+}
+
+func (c *converter) convert(doc openapi_spec.SwaggerDocEntity) OpenAPIEntity {
+	out := OpenAPIEntity{
+		OpenAPI:      DefaultVersion,
+		Info:         doc.Info,
+		Servers:      convertServers(doc),
+		Paths:        make(map[string]PathItemEntity, len(doc.Paths)),
+		Tags:         doc.Tags,
+		ExternalDocs: doc.ExternalDocs,
+	}
+	if c.v31 {
+		out.OpenAPI = DefaultVersion31
+		if strings.HasPrefix(doc.Swagger, "3.1") {
+			out.OpenAPI = doc.Swagger
+		}
+	} else if strings.HasPrefix(doc.Swagger, "3.0") {
+		out.OpenAPI = doc.Swagger
+	}
+
+	for path, item := range doc.Paths {
+		out.Paths[path] = c.convertPathItem(item)
+	}
+	if c.v31 && len(doc.Webhooks) > 0 {
+		out.Webhooks = make(map[string]PathItemEntity, len(doc.Webhooks))
+		for name, item := range doc.Webhooks {
+			out.Webhooks[name] = c.convertPathItem(item)
+		}
+	}
+
+	components := &ComponentsEntity{}
+	if len(doc.Definitions) > 0 {
+		components.Schemas = make(map[string]*SchemaEntity, len(doc.Definitions))
+		for name, def := range doc.Definitions {
+			def := def
+			components.Schemas[name] = c.convertSchema(&def)
+		}
+	}
+	if len(doc.SecurityDefinitions) > 0 {
+		components.SecuritySchemes = make(map[string]SecuritySchemeEntity, len(doc.SecurityDefinitions))
+		for name, scheme := range doc.SecurityDefinitions {
+			components.SecuritySchemes[name] = convertSecurityScheme(scheme)
+		}
+	}
+	if components.Schemas != nil || components.SecuritySchemes != nil {
+		out.Components = components
+	}
+	return out
+}
+
+// convertServers folds host, basePath and schemes into server URLs, since
+// OpenAPI 3 paths are relative to the server URL.
+func convertServers(doc openapi_spec.SwaggerDocEntity) []openapi_spec.ServerEntity {
+	basePath := strings.TrimSuffix(doc.BasePath, "/")
+
+	if len(doc.Servers) > 0 {
+		servers := make([]openapi_spec.ServerEntity, 0, len(doc.Servers))
+		for _, server := range doc.Servers {
+			server.URL = joinServerURL(server.URL, basePath)
+			servers = append(servers, server)
+		}
+		return servers
+	}
+
+	if doc.Host == "" {
+		if basePath == "" {
+			return nil
+		}
+		return []openapi_spec.ServerEntity{{URL: basePath}}
+	}
+
+	schemes := doc.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	servers := make([]openapi_spec.ServerEntity, 0, len(schemes))
+	for _, scheme := range schemes {
+		u := url.URL{Scheme: scheme, Host: doc.Host, Path: basePath}
+		servers = append(servers, openapi_spec.ServerEntity{URL: u.String()})
+	}
+	return servers
+}
+
+func joinServerURL(serverURL, basePath string) string {
+	trimmed := strings.TrimSuffix(serverURL, "/")
+	if basePath == "" || strings.HasSuffix(trimmed, basePath) {
+		if trimmed == "" {
+			return "/"
+		}
+		return trimmed
+	}
+	return trimmed + basePath
+}
+
+func (c *converter) convertPathItem(item openapi_spec.PathItemEntity) PathItemEntity {
+	out := PathItemEntity{Ref: item.Ref}
+
+	// Path level body and formData parameters have no 3.0 equivalent on the
+	// path item, so they are pushed down into every operation's request body.
+	var shared []openapi_spec.ParameterEntity
+	for _, param := range item.Parameters {
+		if param.In == "body" || param.In == "formData" {
+			shared = append(shared, param)
+			continue
+		}
+		out.Parameters = append(out.Parameters, c.convertParameter(param))
+	}
+
+	out.Get = c.convertOperation(item.Get, shared)
+	out.Put = c.convertOperation(item.Put, shared)
+	out.Post = c.convertOperation(item.Post, shared)
+	out.Delete = c.convertOperation(item.Delete, shared)
+	out.Options = c.convertOperation(item.Options, shared)
+	out.Head = c.convertOperation(item.Head, shared)
+	out.Patch = c.convertOperation(item.Patch, shared)
+	return out
+}
+
+func (c *converter) convertOperation(op *openapi_spec.OperationEntity, shared []openapi_spec.ParameterEntity) *OperationEntity {
+	if op == nil {
+		return nil
+	}
+	out := &OperationEntity{
+		Tags:         op.Tags,
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: op.ExternalDocs,
+		OperationID:  op.OperationID,
+		Responses:    make(map[string]ResponseEntity, len(op.Responses)),
+		Deprecated:   op.Deprecated,
+		Security:     op.Security,
+	}
+
+	var body *openapi_spec.ParameterEntity
+	var form []openapi_spec.ParameterEntity
+	params := append(overriddenShared(shared, op.Parameters), op.Parameters...)
+	for i := range params {
+		param := params[i]
+		switch param.In {
+		case "body":
+			body = &param
+		case "formData":
+			form = append(form, param)
+		default:
+			out.Parameters = append(out.Parameters, c.convertParameter(param))
+		}
+	}
+
+	consumes := mimeStrings(op.Consumes)
+	if body != nil {
+		out.RequestBody = c.bodyRequest(*body, consumes)
+	} else if len(form) > 0 {
+		out.RequestBody = c.formRequest(form, consumes)
+	}
+
+	produces := mimeStrings(op.Produces)
+	if len(produces) == 0 {
+		produces = []string{string(mime.ApplicationJSON)}
+	}
+	for code, resp := range op.Responses {
+		out.Responses[code] = c.convertResponse(resp, produces)
+	}
+	return out
+}
+
+// overriddenShared drops path level parameters redefined by the operation.
+func overriddenShared(shared, own []openapi_spec.ParameterEntity) []openapi_spec.ParameterEntity {
+	var result []openapi_spec.ParameterEntity
+	for _, s := range shared {
+		overridden := false
+		for _, p := range own {
+			if p.In == s.In && p.Name == s.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func (c *converter) bodyRequest(param openapi_spec.ParameterEntity, consumes []string) *RequestBodyEntity {
+	if len(consumes) == 0 {
+		consumes = []string{string(mime.ApplicationJSON)}
+	}
+	schema := c.convertSchema(param.Schema)
+	content := make(map[string]MediaTypeEntity, len(consumes))
+	for _, mimeType := range consumes {
+		content[mimeType] = MediaTypeEntity{Schema: schema}
+	}
+	return &RequestBodyEntity{
+		Description: param.Description,
+		Content:     content,
+		Required:    param.Required,
+	}
+}
+
+func (c *converter) formRequest(params []openapi_spec.ParameterEntity, consumes []string) *RequestBodyEntity {
+	schema := &SchemaEntity{
+		Type:       SchemaType{"object"},
+		Properties: make(map[string]*SchemaEntity, len(params)),
+	}
+	hasFile := false
+	for _, param := range params {
+		if param.Type == "file" {
+			hasFile = true
+		}
+		prop := c.parameterSchema(param)
+		prop.Description = param.Description
+		schema.Properties[param.Name] = prop
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+
+	var formTypes []string
+	for _, mimeType := range consumes {
+		if mimeType == "multipart/form-data" || mimeType == "application/x-www-form-urlencoded" {
+			formTypes = append(formTypes, mimeType)
+		}
+	}
+	if len(formTypes) == 0 {
+		if hasFile {
+			formTypes = []string{"multipart/form-data"}
+		} else {
+			formTypes = []string{"application/x-www-form-urlencoded"}
+		}
+	}
+
+	content := make(map[string]MediaTypeEntity, len(formTypes))
+	for _, mimeType := range formTypes {
+		content[mimeType] = MediaTypeEntity{Schema: schema}
+	}
+	return &RequestBodyEntity{Content: content, Required: len(schema.Required) > 0}
+}
+
+func (c *converter) convertParameter(param openapi_spec.ParameterEntity) ParameterEntity {
+	out := ParameterEntity{
+		Name:            param.Name,
+		In:              param.In,
+		Description:     param.Description,
+		Required:        param.Required,
+		AllowEmptyValue: param.AllowEmptyValue,
+		Schema:          c.parameterSchema(param),
+	}
+	if param.Type == "array" {
+		out.Style, out.Explode = collectionStyle(param.In, param.CollectionFormat)
+	}
+	return out
+}
+
+// collectionStyle maps a Swagger 2.0 collectionFormat onto style and explode.
+func collectionStyle(in, collectionFormat string) (string, *bool) {
+	explode := false
+	switch collectionFormat {
+	case "multi":
+		explode = true
+		return "form", &explode
+	case "ssv":
+		return "spaceDelimited", &explode
+	case "pipes":
+		return "pipeDelimited", &explode
+	case "", "csv":
+		if in == "query" || in == "cookie" {
+			return "form", &explode
+		}
+		return "simple", &explode
+	}
+	return "", nil
+}
+
+// parameterSchema builds the schema of a non-body parameter from its inline
+// type and validation fields.
+func (c *converter) parameterSchema(param openapi_spec.ParameterEntity) *SchemaEntity {
+	if param.Schema != nil {
+		return c.convertSchema(param.Schema)
+	}
+	schema := &SchemaEntity{
+		Type:        schemaType(param.Type),
+		Format:      param.Format,
+		Items:       c.convertSchema(param.Items),
+		Default:     param.Default,
+		MaxLength:   param.MaxLength,
+		MinLength:   param.MinLength,
+		Pattern:     param.Pattern,
+		MaxItems:    param.MaxItems,
+		MinItems:    param.MinItems,
+		UniqueItems: param.UniqueItems,
+		Enum:        param.Enum,
+		MultipleOf:  param.MultipleOf,
+	}
+	schema.EnumVarNames, schema.EnumDescriptions = param.EnumVarNames, param.EnumDescriptions
+	if param.Type == "file" {
+		schema.Format = "binary"
+	}
+	if schema.Type == nil {
+		schema.Type = SchemaType{"string"}
+	}
+	c.setBounds(schema, param.Maximum, param.ExclusiveMaximum, param.Minimum, param.ExclusiveMinimum)
+	return c.adapt(schema, false)
+}
+
+func (c *converter) convertResponse(resp openapi_spec.ResponseEntity, produces []string) ResponseEntity {
+	out := ResponseEntity{Description: resp.Description}
+
+	if len(resp.Headers) > 0 {
+		out.Headers = make(map[string]HeaderEntity, len(resp.Headers))
+		for name, header := range resp.Headers {
+			out.Headers[name] = c.convertHeader(header)
+		}
+	}
+
+	if resp.Schema != nil {
+		schema := c.convertSchema(resp.Schema)
+		out.Content = make(map[string]MediaTypeEntity, len(produces))
+		for _, mimeType := range produces {
+			out.Content[mimeType] = MediaTypeEntity{Schema: schema}
+		}
+	}
+
+	if len(resp.Examples) > 0 {
+		if out.Content == nil {
+			out.Content = make(map[string]MediaTypeEntity, len(resp.Examples))
+		}
+		for mimeType, example := range resp.Examples {
+			media := out.Content[mimeType]
+			media.Example = example
+			out.Content[mimeType] = media
+		}
+	}
+	return out
+}
+
+func (c *converter) convertHeader(header openapi_spec.HeaderEntity) HeaderEntity {
+	schema := &SchemaEntity{
+		Type:        schemaType(header.Type),
+		Format:      header.Format,
+		Items:       c.convertSchema(header.Items),
+		Default:     header.Default,
+		MaxLength:   header.MaxLength,
+		MinLength:   header.MinLength,
+		Pattern:     header.Pattern,
+		MaxItems:    header.MaxItems,
+		MinItems:    header.MinItems,
+		UniqueItems: header.UniqueItems,
+		Enum:        header.Enum,
+		MultipleOf:  header.MultipleOf,
+	}
+	c.setBounds(schema, header.Maximum, header.ExclusiveMaximum, header.Minimum, header.ExclusiveMinimum)
+	return HeaderEntity{
+		Description: header.Description,
+		Schema:      c.adapt(schema, false),
+	}
+}
+
+func (c *converter) convertSchema(schema *openapi_spec.SchemaEntity) *SchemaEntity {
+	if schema == nil {
+		return nil
+	}
+	out := &SchemaEntity{
+		Ref:           convertRef(schema.Ref),
+		Title:         schema.Title,
+		Description:   schema.Description,
+		Type:          schemaType(schema.Type),
+		Format:        schema.Format,
+		Default:       schema.Default,
+		MultipleOf:    schema.MultipleOf,
+		MaxLength:     schema.MaxLength,
+		MinLength:     schema.MinLength,
+		Pattern:       schema.Pattern,
+		MaxItems:      schema.MaxItems,
+		MinItems:      schema.MinItems,
+		UniqueItems:   schema.UniqueItems,
+		MaxProperties: schema.MaxProperties,
+		MinProperties: schema.MinProperties,
+		Required:      schema.Required,
+		Enum:          schema.Enum,
+		Items:         c.convertSchema(schema.Items),
+		ReadOnly:      schema.ReadOnly,
+		XML:           schema.XML,
+		ExternalDocs:  schema.ExternalDocs,
+		Example:       schema.Example,
+	}
+	out.EnumVarNames, out.EnumDescriptions = schema.EnumVarNames, schema.EnumDescriptions
+	c.setBounds(out, schema.Maximum, schema.ExclusiveMaximum, schema.Minimum, schema.ExclusiveMinimum)
+	if schema.Type == "file" {
+		out.Format = "binary"
+	}
+	if schema.Discriminator != "" {
+		out.Discriminator = &DiscriminatorEntity{PropertyName: schema.Discriminator}
+	}
+	for _, sub := range schema.AllOf {
+		out.AllOf = append(out.AllOf, c.convertSchema(sub))
+	}
+	if len(schema.Properties) > 0 {
+		out.Properties = make(map[string]*SchemaEntity, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			if prop != nil && schema.NullableProperties[name] {
+				nullable := *prop
+				nullable.Nullable = true
+				prop = &nullable
+			}
+			out.Properties[name] = c.convertSchema(prop)
+		}
+	}
+	switch additional := schema.AdditionalProperties.(type) {
+	case nil:
+	case *openapi_spec.SchemaEntity:
+		out.AdditionalProperties = c.convertSchema(additional)
+	case openapi_spec.SchemaEntity:
+		out.AdditionalProperties = c.convertSchema(&additional)
+	default:
+		out.AdditionalProperties = additional
+	}
+	return c.adapt(out, schema.Nullable)
+}
+
+func schemaType(t string) SchemaType {
+	switch t {
+	case "":
+		return nil
+	case "file":
+		return SchemaType{"string"}
+	}
+	return SchemaType{t}
+}
+
+// setBounds writes maximum and minimum in the version's shape: 3.0 keeps the
+// boolean exclusive flags, 3.1 turns them into numeric exclusive bounds.
+func (c *converter) setBounds(out *SchemaEntity, max *float64, exclusiveMax bool, min *float64, exclusiveMin bool) {
+	out.Maximum, out.Minimum = max, min
+	if max != nil && exclusiveMax {
+		if c.v31 {
+			out.ExclusiveMaximum, out.Maximum = *max, nil
+		} else {
+			out.ExclusiveMaximum = true
+		}
+	}
+	if min != nil && exclusiveMin {
+		if c.v31 {
+			out.ExclusiveMinimum, out.Minimum = *min, nil
+		} else {
+			out.ExclusiveMinimum = true
+		}
+	}
+}
+
+// adapt applies the version specific keywords to a converted schema: file
+// formats, nullability, examples, const and the treatment of $ref siblings.
+func (c *converter) adapt(out *SchemaEntity, nullable bool) *SchemaEntity {
+	if !c.v31 {
+		out.Nullable = nullable
+		if out.Ref != "" {
+			rest := *out
+			rest.Ref = ""
+			if !reflect.ValueOf(rest).IsZero() {
+				// $ref siblings are ignored in 3.0, so they are kept next to an allOf.
+				rest.AllOf = append([]*SchemaEntity{{Ref: out.Ref}}, rest.AllOf...)
+				return &rest
+			}
+		}
+		return out
+	}
+
+	if out.Example != nil {
+		out.Examples = []interface{}{out.Example}
+		out.Example = nil
+	}
+	if len(out.Enum) == 1 && !nullable {
+		out.Const = out.Enum[0]
+		out.Enum = nil
+	}
+	if nullable {
+		switch {
+		case out.Ref != "":
+			out.AnyOf = append(out.AnyOf, &SchemaEntity{Ref: out.Ref}, &SchemaEntity{Type: SchemaType{"null"}})
+			out.Ref = ""
+		case len(out.AllOf) == 1 && len(out.Type) == 0: // a described $ref
+			out.AnyOf = append(out.AnyOf, out.AllOf[0], &SchemaEntity{Type: SchemaType{"null"}})
+			out.AllOf = nil
+		case len(out.Type) > 0:
+			out.Type = append(out.Type, "null")
+			if out.Enum != nil {
+				out.Enum = append(out.Enum, nil)
+			}
+		}
+	}
+	return out
+}
+
+func convertRef(ref string) string {
+	if strings.HasPrefix(ref, swagger2RefPrefix) {
+		return schemasRefPrefix + strings.TrimPrefix(ref, swagger2RefPrefix)
+	}
+	return ref
+}
+
+func convertSecurityScheme(scheme openapi_spec.SecuritySchemeEntity) SecuritySchemeEntity {
+	out := SecuritySchemeEntity{
+		Type:        scheme.Type,
+		Description: scheme.Description,
+	}
+	switch scheme.Type {
+	case "basic":
+		out.Type = "http"
+		out.Scheme = "basic"
+	case "apiKey":
+		out.Name = scheme.Name
+		out.In = scheme.In
+	case "oauth2":
+		scopes := scheme.Scopes
+		if scopes == nil {
+			scopes = map[string]string{}
+		}
+		flow := &OAuthFlowEntity{
+			AuthorizationURL: scheme.AuthorizationURL,
+			TokenURL:         scheme.TokenURL,
+			Scopes:           scopes,
+		}
+		flows := &OAuthFlowsEntity{}
+		switch scheme.Flow {
+		case "implicit":
+			flow.TokenURL = ""
+			flows.Implicit = flow
+		case "password":
+			flow.AuthorizationURL = ""
+			flows.Password = flow
+		case "application":
+			flow.AuthorizationURL = ""
+			flows.ClientCredentials = flow
+		case "accessCode":
+			flows.AuthorizationCode = flow
+		}
+		out.Flows = flows
+	}
+	return out
+}
+
+func mimeStrings(mimeTypes []mime.MimeType) []string {
+	result := make([]string, 0, len(mimeTypes))
+	seen := make(map[string]bool, len(mimeTypes))
+	for _, mimeType := range mimeTypes {
+		if !seen[string(mimeType)] {
+			seen[string(mimeType)] = true
+			result = append(result, string(mimeType))
+		}
+	}
+	return result
+}
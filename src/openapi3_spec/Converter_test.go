@@ -0,0 +1,227 @@
+package openapi3_spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec/mime"
+)
+
+// jsonOf returns the compact JSON encoding of v.
+func jsonOf(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestConvertServers(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  openapi_spec.SwaggerDocEntity
+		want string
+	}{
+		{
+			name: "nothing",
+			doc:  openapi_spec.SwaggerDocEntity{},
+			want: `null`,
+		},
+		{
+			name: "base path only",
+			doc:  openapi_spec.SwaggerDocEntity{BasePath: "/v1/"},
+			want: `[{"url":"/v1"}]`,
+		},
+		{
+			name: "host without schemes",
+			doc:  openapi_spec.SwaggerDocEntity{Host: "api.example.com", BasePath: "/v1"},
+			want: `[{"url":"https://api.example.com/v1"}]`,
+		},
+		{
+			name: "host with schemes",
+			doc:  openapi_spec.SwaggerDocEntity{Host: "localhost:8080", Schemes: []string{"http", "https"}},
+			want: `[{"url":"http://localhost:8080"},{"url":"https://localhost:8080"}]`,
+		},
+		{
+			name: "servers take precedence over host",
+			doc: openapi_spec.SwaggerDocEntity{
+				Host:    "ignored.example.com",
+				Servers: []openapi_spec.ServerEntity{{URL: "https://api.example.com/", Description: "Production"}},
+			},
+			want: `[{"url":"https://api.example.com","description":"Production"}]`,
+		},
+		{
+			name: "servers joined with the base path",
+			doc: openapi_spec.SwaggerDocEntity{
+				BasePath: "/v1",
+				Servers:  []openapi_spec.ServerEntity{{URL: "/"}, {URL: "https://api.example.com"}, {URL: "https://api.example.com/v1/"}},
+			},
+			want: `[{"url":"/v1"},{"url":"https://api.example.com/v1"},{"url":"https://api.example.com/v1"}]`,
+		},
+		{
+			name: "root server without base path",
+			doc:  openapi_spec.SwaggerDocEntity{Servers: []openapi_spec.ServerEntity{{URL: "/"}}},
+			want: `[{"url":"/"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonOf(t, FromSwagger2(tt.doc).Servers); got != tt.want {
+				t.Errorf("servers %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertDefinitions(t *testing.T) {
+	doc := openapi_spec.SwaggerDocEntity{
+		Definitions: map[string]openapi_spec.SchemaEntity{
+			"Pet": {
+				Type:     "object",
+				Required: []string{"name"},
+				Properties: map[string]*openapi_spec.SchemaEntity{
+					"name":     {Type: "string"},
+					"category": {Ref: "#/definitions/Category"},
+					"tags":     {Type: "array", Items: &openapi_spec.SchemaEntity{Ref: "#/definitions/Tag"}},
+				},
+			},
+			"Category": {Type: "object", AdditionalProperties: &openapi_spec.SchemaEntity{Ref: "#/definitions/Tag"}},
+			"Tag":      {Type: "object", Properties: map[string]*openapi_spec.SchemaEntity{"name": {Type: "string"}}},
+		},
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Pet", `{"type":"object","required":["name"],"properties":{"category":{"$ref":"#/components/schemas/Category"},"name":{"type":"string"},"tags":{"type":"array","items":{"$ref":"#/components/schemas/Tag"}}}}`},
+		{"Category", `{"type":"object","additionalProperties":{"$ref":"#/components/schemas/Tag"}}`},
+		{"Tag", `{"type":"object","properties":{"name":{"type":"string"}}}`},
+	}
+	out := FromSwagger2(doc)
+	if out.Components == nil || len(out.Components.Schemas) != len(tests) {
+		t.Fatalf("components %s, want %d schemas", jsonOf(t, out.Components), len(tests))
+	}
+	for _, tt := range tests {
+		if got := jsonOf(t, out.Components.Schemas[tt.name]); got != tt.want {
+			t.Errorf("schema %s: %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConvertRequestBodies(t *testing.T) {
+	petRef := &openapi_spec.SchemaEntity{Ref: "#/definitions/Pet"}
+	tests := []struct {
+		name      string
+		item      openapi_spec.PathItemEntity
+		wantBody  string
+		wantParam string
+	}{
+		{
+			name: "body with the default content type",
+			item: openapi_spec.PathItemEntity{Post: &openapi_spec.OperationEntity{
+				Parameters: []openapi_spec.ParameterEntity{{Name: "pet", In: "body", Description: "Pet to add", Required: true, Schema: petRef}},
+			}},
+			wantBody:  `{"description":"Pet to add","content":{"application/json":{"schema":{"$ref":"#/components/schemas/Pet"}}},"required":true}`,
+			wantParam: `null`,
+		},
+		{
+			name: "body with consumes",
+			item: openapi_spec.PathItemEntity{Post: &openapi_spec.OperationEntity{
+				Consumes:   []mime.MimeType{mime.ApplicationJSON, mime.ApplicationXML, mime.ApplicationJSON},
+				Parameters: []openapi_spec.ParameterEntity{{Name: "pet", In: "body", Schema: petRef}},
+			}},
+			wantBody:  `{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Pet"}},"application/xml":{"schema":{"$ref":"#/components/schemas/Pet"}}}}`,
+			wantParam: `null`,
+		},
+		{
+			name: "form fields",
+			item: openapi_spec.PathItemEntity{Post: &openapi_spec.OperationEntity{
+				Parameters: []openapi_spec.ParameterEntity{
+					{Name: "id", In: "path", Required: true, Type: "integer"},
+					{Name: "name", In: "formData", Description: "Name of the pet", Required: true, Type: "string"},
+					{Name: "status", In: "formData", Type: "string"},
+				},
+			}},
+			wantBody:  `{"content":{"application/x-www-form-urlencoded":{"schema":{"type":"object","required":["name"],"properties":{"name":{"description":"Name of the pet","type":"string"},"status":{"type":"string"}}}}},"required":true}`,
+			wantParam: `[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}]`,
+		},
+		{
+			name: "file upload",
+			item: openapi_spec.PathItemEntity{Post: &openapi_spec.OperationEntity{
+				Parameters: []openapi_spec.ParameterEntity{{Name: "file", In: "formData", Type: "file"}},
+			}},
+			wantBody:  `{"content":{"multipart/form-data":{"schema":{"type":"object","properties":{"file":{"type":"string","format":"binary"}}}}}}`,
+			wantParam: `null`,
+		},
+		{
+			name: "form fields with consumes",
+			item: openapi_spec.PathItemEntity{Post: &openapi_spec.OperationEntity{
+				Consumes:   []mime.MimeType{"multipart/form-data", mime.ApplicationJSON},
+				Parameters: []openapi_spec.ParameterEntity{{Name: "name", In: "formData", Type: "string"}},
+			}},
+			wantBody:  `{"content":{"multipart/form-data":{"schema":{"type":"object","properties":{"name":{"type":"string"}}}}}}`,
+			wantParam: `null`,
+		},
+		{
+			name: "path level body",
+			item: openapi_spec.PathItemEntity{
+				Parameters: []openapi_spec.ParameterEntity{{Name: "pet", In: "body", Schema: petRef}, {Name: "id", In: "path", Required: true, Type: "string"}},
+				Post:       &openapi_spec.OperationEntity{},
+			},
+			wantBody:  `{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Pet"}}}}`,
+			wantParam: `null`,
+		},
+		{
+			name: "path level body overridden by the operation",
+			item: openapi_spec.PathItemEntity{
+				Parameters: []openapi_spec.ParameterEntity{{Name: "pet", In: "body", Schema: petRef}},
+				Post: &openapi_spec.OperationEntity{
+					Parameters: []openapi_spec.ParameterEntity{{Name: "pet", In: "body", Required: true, Schema: &openapi_spec.SchemaEntity{Type: "string"}}},
+				},
+			},
+			wantBody:  `{"content":{"application/json":{"schema":{"type":"string"}}},"required":true}`,
+			wantParam: `null`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := openapi_spec.SwaggerDocEntity{Paths: map[string]openapi_spec.PathItemEntity{"/pets/{id}": tt.item}}
+			op := FromSwagger2(doc).Paths["/pets/{id}"].Post
+			if got := jsonOf(t, op.RequestBody); got != tt.wantBody {
+				t.Errorf("request body %s, want %s", got, tt.wantBody)
+			}
+			if got := jsonOf(t, op.Parameters); got != tt.wantParam {
+				t.Errorf("parameters %s, want %s", got, tt.wantParam)
+			}
+		})
+	}
+}
+
+func TestConvertRefs(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi_spec.SchemaEntity
+		want   string
+	}{
+		{"definition", &openapi_spec.SchemaEntity{Ref: "#/definitions/Pet"}, `{"$ref":"#/components/schemas/Pet"}`},
+		{"external", &openapi_spec.SchemaEntity{Ref: "common.json#/definitions/Pet"}, `{"$ref":"common.json#/definitions/Pet"}`},
+		{"array items", &openapi_spec.SchemaEntity{Type: "array", Items: &openapi_spec.SchemaEntity{Ref: "#/definitions/Pet"}}, `{"type":"array","items":{"$ref":"#/components/schemas/Pet"}}`},
+		{"allOf", &openapi_spec.SchemaEntity{AllOf: []*openapi_spec.SchemaEntity{{Ref: "#/definitions/Pet"}}}, `{"allOf":[{"$ref":"#/components/schemas/Pet"}]}`},
+		{"described", &openapi_spec.SchemaEntity{Ref: "#/definitions/Pet", Description: "The pet"}, `{"description":"The pet","allOf":[{"$ref":"#/components/schemas/Pet"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := openapi_spec.SwaggerDocEntity{Paths: map[string]openapi_spec.PathItemEntity{
+				"/pets": {Get: &openapi_spec.OperationEntity{Responses: map[string]openapi_spec.ResponseEntity{
+					"200": {Description: "OK", Schema: tt.schema},
+				}}},
+			}}
+			resp := FromSwagger2(doc).Paths["/pets"].Get.Responses["200"]
+			if got := jsonOf(t, resp.Content["application/json"].Schema); got != tt.want {
+				t.Errorf("schema %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package openapi3_spec
+
+type HeaderEntity struct {
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Deprecated  bool          `json:"deprecated,omitempty"`
+	Style       string        `json:"style,omitempty"`
+	Explode     *bool         `json:"explode,omitempty"`
+	Schema      *SchemaEntity `json:"schema,omitempty"`
+}
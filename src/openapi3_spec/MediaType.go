@@ -0,0 +1,14 @@
+package openapi3_spec
+
+type MediaTypeEntity struct {
+	Schema   *SchemaEntity             `json:"schema,omitempty"`
+	Example  interface{}               `json:"example,omitempty"`
+	Encoding map[string]EncodingEntity `json:"encoding,omitempty"`
+}
+
+type EncodingEntity struct {
+	ContentType string                  `json:"contentType,omitempty"`
+	Headers     map[string]HeaderEntity `json:"headers,omitempty"`
+	Style       string                  `json:"style,omitempty"`
+	Explode     *bool                   `json:"explode,omitempty"`
+}
@@ -0,0 +1,15 @@
+package openapi3_spec
+
+type OAuthFlowsEntity struct {
+	Implicit          *OAuthFlowEntity `json:"implicit,omitempty"`
+	Password          *OAuthFlowEntity `json:"password,omitempty"`
+	ClientCredentials *OAuthFlowEntity `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlowEntity `json:"authorizationCode,omitempty"`
+}
+
+type OAuthFlowEntity struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
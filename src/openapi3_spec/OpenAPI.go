@@ -0,0 +1,23 @@
+package openapi3_spec
+
+import (
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+type OpenAPIEntity struct {
+	OpenAPI           string                                    `json:"openapi"`
+	Info              openapi_spec.InfoEntity                   `json:"info"`
+	JSONSchemaDialect string                                    `json:"jsonSchemaDialect,omitempty"`
+	Servers           []openapi_spec.ServerEntity               `json:"servers,omitempty"`
+	Paths             map[string]PathItemEntity                 `json:"paths"`
+	Webhooks          map[string]PathItemEntity                 `json:"webhooks,omitempty"`
+	Components        *ComponentsEntity                         `json:"components,omitempty"`
+	Security          []map[string][]string                     `json:"security,omitempty"`
+	Tags              []openapi_spec.TagEntity                  `json:"tags,omitempty"`
+	ExternalDocs      *openapi_spec.ExternalDocumentationEntity `json:"externalDocs,omitempty"`
+}
+
+// MarshalYAML lets yaml.Marshal encode the document in struct tag order.
+func (d OpenAPIEntity) MarshalYAML() (interface{}, error) {
+	return openapi_spec.YAMLNode(d)
+}
@@ -0,0 +1,19 @@
+package openapi3_spec
+
+import (
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+type OperationEntity struct {
+	Tags         []string                                  `json:"tags,omitempty"`
+	Summary      string                                    `json:"summary,omitempty"`
+	Description  string                                    `json:"description,omitempty"`
+	ExternalDocs *openapi_spec.ExternalDocumentationEntity `json:"externalDocs,omitempty"`
+	OperationID  string                                    `json:"operationId,omitempty"`
+	Parameters   []ParameterEntity                         `json:"parameters,omitempty"`
+	RequestBody  *RequestBodyEntity                        `json:"requestBody,omitempty"`
+	Responses    map[string]ResponseEntity                 `json:"responses"`
+	Deprecated   bool                                      `json:"deprecated,omitempty"`
+	Security     []map[string][]string                     `json:"security,omitempty"`
+	Servers      []openapi_spec.ServerEntity               `json:"servers,omitempty"`
+}
@@ -0,0 +1,14 @@
+package openapi3_spec
+
+type ParameterEntity struct {
+	Name            string        `json:"name"`
+	In              string        `json:"in"`
+	Description     string        `json:"description,omitempty"`
+	Required        bool          `json:"required,omitempty"`
+	Deprecated      bool          `json:"deprecated,omitempty"`
+	AllowEmptyValue bool          `json:"allowEmptyValue,omitempty"`
+	Style           string        `json:"style,omitempty"`
+	Explode         *bool         `json:"explode,omitempty"`
+	Schema          *SchemaEntity `json:"schema,omitempty"`
+	Example         interface{}   `json:"example,omitempty"`
+}
@@ -0,0 +1,21 @@
+package openapi3_spec
+
+import (
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+type PathItemEntity struct {
+	Ref         string                      `json:"$ref,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Get         *OperationEntity            `json:"get,omitempty"`
+	Put         *OperationEntity            `json:"put,omitempty"`
+	Post        *OperationEntity            `json:"post,omitempty"`
+	Delete      *OperationEntity            `json:"delete,omitempty"`
+	Options     *OperationEntity            `json:"options,omitempty"`
+	Head        *OperationEntity            `json:"head,omitempty"`
+	Patch       *OperationEntity            `json:"patch,omitempty"`
+	Trace       *OperationEntity            `json:"trace,omitempty"`
+	Servers     []openapi_spec.ServerEntity `json:"servers,omitempty"`
+	Parameters  []ParameterEntity           `json:"parameters,omitempty"`
+}
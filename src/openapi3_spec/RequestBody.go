@@ -0,0 +1,7 @@
+package openapi3_spec
+
+type RequestBodyEntity struct {
+	Description string                     `json:"description,omitempty"`
+	Content     map[string]MediaTypeEntity `json:"content"`
+	Required    bool                       `json:"required,omitempty"`
+}
@@ -0,0 +1,7 @@
+package openapi3_spec
+
+type ResponseEntity struct {
+	Description string                     `json:"description"`
+	Headers     map[string]HeaderEntity    `json:"headers,omitempty"`
+	Content     map[string]MediaTypeEntity `json:"content,omitempty"`
+}
@@ -0,0 +1,80 @@
+package openapi3_spec
+
+import (
+	"encoding/json"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+type SchemaEntity struct {
+	Ref                  string                                    `json:"$ref,omitempty"`
+	Title                string                                    `json:"title,omitempty"`
+	Description          string                                    `json:"description,omitempty"`
+	Type                 SchemaType                                `json:"type,omitempty"`
+	Format               string                                    `json:"format,omitempty"`
+	Default              interface{}                               `json:"default,omitempty"`
+	Const                interface{}                               `json:"const,omitempty"` // 3.1 only
+	MultipleOf           *float64                                  `json:"multipleOf,omitempty"`
+	Maximum              *float64                                  `json:"maximum,omitempty"`
+	ExclusiveMaximum     interface{}                               `json:"exclusiveMaximum,omitempty"` // bool in 3.0, number in 3.1
+	Minimum              *float64                                  `json:"minimum,omitempty"`
+	ExclusiveMinimum     interface{}                               `json:"exclusiveMinimum,omitempty"` // bool in 3.0, number in 3.1
+	MaxLength            *int                                      `json:"maxLength,omitempty"`
+	MinLength            *int                                      `json:"minLength,omitempty"`
+	Pattern              string                                    `json:"pattern,omitempty"`
+	MaxItems             *int                                      `json:"maxItems,omitempty"`
+	MinItems             *int                                      `json:"minItems,omitempty"`
+	UniqueItems          bool                                      `json:"uniqueItems,omitempty"`
+	MaxProperties        *int                                      `json:"maxProperties,omitempty"`
+	MinProperties        *int                                      `json:"minProperties,omitempty"`
+	Required             []string                                  `json:"required,omitempty"`
+	Enum                 []interface{}                             `json:"enum,omitempty"`
+	EnumVarNames         []string                                  `json:"x-enum-varnames,omitempty"`
+	EnumDescriptions     []string                                  `json:"x-enum-descriptions,omitempty"`
+	Items                *SchemaEntity                             `json:"items,omitempty"`
+	AllOf                []*SchemaEntity                           `json:"allOf,omitempty"`
+	OneOf                []*SchemaEntity                           `json:"oneOf,omitempty"`
+	AnyOf                []*SchemaEntity                           `json:"anyOf,omitempty"`
+	Not                  *SchemaEntity                             `json:"not,omitempty"`
+	Properties           map[string]*SchemaEntity                  `json:"properties,omitempty"`
+	AdditionalProperties interface{}                               `json:"additionalProperties,omitempty"` // bool or *SchemaEntity
+	Discriminator        *DiscriminatorEntity                      `json:"discriminator,omitempty"`
+	Nullable             bool                                      `json:"nullable,omitempty"` // 3.0 only
+	ReadOnly             bool                                      `json:"readOnly,omitempty"`
+	WriteOnly            bool                                      `json:"writeOnly,omitempty"`
+	Deprecated           bool                                      `json:"deprecated,omitempty"`
+	XML                  *openapi_spec.XMLObjectEntity             `json:"xml,omitempty"`
+	ExternalDocs         *openapi_spec.ExternalDocumentationEntity `json:"externalDocs,omitempty"`
+	Example              interface{}                               `json:"example,omitempty"`  // 3.0 only
+	Examples             []interface{}                             `json:"examples,omitempty"` // 3.1 only
+}
+
+type DiscriminatorEntity struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// SchemaType is written as a single string when it holds one type and as an
+// array otherwise, e.g. ["string", "null"] in OpenAPI 3.1.
+type SchemaType []string
+
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaType{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*t = multiple
+	return nil
+}
@@ -0,0 +1,12 @@
+package openapi3_spec
+
+type SecuritySchemeEntity struct {
+	Type             string            `json:"type"`
+	Description      string            `json:"description,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	In               string            `json:"in,omitempty"`
+	Scheme           string            `json:"scheme,omitempty"`
+	BearerFormat     string            `json:"bearerFormat,omitempty"`
+	Flows            *OAuthFlowsEntity `json:"flows,omitempty"`
+	OpenIDConnectURL string            `json:"openIdConnectUrl,omitempty"`
+}
@@ -23,5 +23,7 @@ type ParameterEntity struct {
 	MinItems         *int          `json:"minItems,omitempty"`
 	UniqueItems      bool          `json:"uniqueItems,omitempty"`
 	Enum             []interface{} `json:"enum,omitempty"`
+	EnumVarNames     []string      `json:"x-enum-varnames,omitempty"`
+	EnumDescriptions []string      `json:"x-enum-descriptions,omitempty"`
 	MultipleOf       *float64      `json:"multipleOf,omitempty"`
 }
@@ -21,6 +21,8 @@ type SchemaEntity struct {
 	MinProperties        *int                         `json:"minProperties,omitempty"`
 	Required             []string                     `json:"required,omitempty"`
 	Enum                 []interface{}                `json:"enum,omitempty"`
+	EnumVarNames         []string                     `json:"x-enum-varnames,omitempty"`     // names of the Enum values for code generators
+	EnumDescriptions     []string                     `json:"x-enum-descriptions,omitempty"` // descriptions of the Enum values
 	Type                 string                       `json:"type,omitempty"`
 	Items                *SchemaEntity                `json:"items,omitempty"`
 	AllOf                []*SchemaEntity              `json:"allOf,omitempty"`
@@ -31,4 +33,9 @@ type SchemaEntity struct {
 	XML                  *XMLObjectEntity             `json:"xml,omitempty"`
 	ExternalDocs         *ExternalDocumentationEntity `json:"externalDocs,omitempty"`
 	Example              interface{}                  `json:"example,omitempty"`
+	Nullable             bool                         `json:"-"` // No Swagger 2.0 equivalent, rendered by the OpenAPI 3 converters
+	// NullableProperties marks the properties that may be null when they are
+	// a $ref, whose siblings Swagger 2.0 ignores. Rendered by the OpenAPI 3
+	// converters like Nullable.
+	NullableProperties map[string]bool `json:"-"`
 }
@@ -1,6 +1,13 @@
 package openapi_spec
 
 type ServerEntity struct {
-	URL         string `json:"url"`
-	Description string `json:"description,omitempty"`
+	URL         string                          `json:"url"`
+	Description string                          `json:"description,omitempty"`
+	Variables   map[string]ServerVariableEntity `json:"variables,omitempty"`
+}
+
+type ServerVariableEntity struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
 }
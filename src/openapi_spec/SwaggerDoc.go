@@ -5,11 +5,12 @@ type SwaggerDocEntity struct {
 	Info                InfoEntity                      `json:"info"`
 	Host                string                          `json:"host,omitempty"`
 	BasePath            string                          `json:"basePath,omitempty"`
-	Servers             []ServerEntity                  `json:"servers,omitempty"`
+	Servers             []ServerEntity                  `json:"-"` // OpenAPI 3 only, not part of Swagger 2.0
 	Tags                []TagEntity                     `json:"tags,omitempty"`
 	Schemes             []string                        `json:"schemes,omitempty"`
 	Paths               map[string]PathItemEntity       `json:"paths"`
 	SecurityDefinitions map[string]SecuritySchemeEntity `json:"securityDefinitions,omitempty"`
 	Definitions         map[string]SchemaEntity         `json:"definitions,omitempty"`
 	ExternalDocs        *ExternalDocumentationEntity    `json:"externalDocs,omitempty"`
+	Webhooks            map[string]PathItemEntity       `json:"-"` // OpenAPI 3.1 only
 }
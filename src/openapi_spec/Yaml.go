@@ -0,0 +1,113 @@
+package openapi_spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToYAML encodes v as YAML. Keys keep the order of the JSON encoding, so
+// struct fields follow their declaration (struct tag) order and map keys are
+// sorted, the same as in the served JSON document.
+func ToYAML(v interface{}) ([]byte, error) {
+	node, err := YAMLNode(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// YAMLNode converts the JSON encoding of v into an ordered YAML node tree.
+func YAMLNode(v interface{}) (*yaml.Node, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decodeYAMLNode(decoder)
+}
+
+// MarshalYAML lets yaml.Marshal encode the document in struct tag order.
+func (d SwaggerDocEntity) MarshalYAML() (interface{}, error) {
+	return YAMLNode(d)
+}
+
+var yaml11Bools = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "on": true, "off": true,
+}
+
+func decodeYAMLNode(decoder *json.Decoder) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch value := token.(type) {
+	case json.Delim:
+		switch value {
+		case '{':
+			node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := decodeYAMLNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, stringNode(key.(string)), child)
+			}
+			_, err = decoder.Token() // closing '}'
+			return node, err
+		case '[':
+			node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for decoder.More() {
+				child, err := decodeYAMLNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, child)
+			}
+			_, err = decoder.Token() // closing ']'
+			return node, err
+		}
+		return nil, fmt.Errorf("unexpected JSON delimiter %q", value)
+	case json.Number:
+		tag := "!!int"
+		if _, err := value.Int64(); err != nil {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value.String()}, nil
+	case string:
+		return stringNode(value), nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(value)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", token)
+}
+
+// stringNode returns a string scalar. The encoder quotes the strings YAML 1.2
+// would read as another type, such as "1e3" or "~", and the ones YAML 1.1
+// parsers would read as booleans are quoted here, keys included.
+func stringNode(value string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if yaml11Bools[strings.ToLower(value)] {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	return node
+}
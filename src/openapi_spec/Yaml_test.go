@@ -0,0 +1,134 @@
+package openapi_spec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestToYAMLQuoting(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"yes", `"yes"`},
+		{"no", `"no"`},
+		{"on", `"on"`},
+		{"off", `"off"`},
+		{"Y", `"Y"`},
+		{"NO", `"NO"`},
+		{"true", `"true"`},
+		{"1e3", `"1e3"`},
+		{"10", `"10"`},
+		{"1.5", `"1.5"`},
+		{"~", `"~"`},
+		{"null", `"null"`},
+		{"", `""`},
+		{"a: b", `'a: b'`},
+		{"#tag", `'#tag'`},
+		{"available", `available`},
+	}
+	for _, tt := range tests {
+		out, err := ToYAML(map[string]string{"value": tt.value})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSpace(string(out)); got != "value: "+tt.want {
+			t.Errorf("ToYAML(%q) = %s, want value: %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestToYAMLQuotesKeys(t *testing.T) {
+	out, err := ToYAML(map[string]int{"on": 1, "200": 2, "name": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\"200\": 2\nname: 3\n\"on\": 1\n"
+	if string(out) != want {
+		t.Errorf("ToYAML = %q, want %q", out, want)
+	}
+}
+
+func TestToYAMLRoundTrip(t *testing.T) {
+	max := 100.0
+	doc := SwaggerDocEntity{
+		Swagger:  "2.0",
+		Info:     InfoEntity{Title: "Pets", Version: "1.0"},
+		BasePath: "/v2",
+		Paths: map[string]PathItemEntity{
+			"/pets/{id}": {Get: &OperationEntity{
+				Parameters: []ParameterEntity{{Name: "id", In: "path", Required: true, Type: "integer", Maximum: &max}},
+				Responses: map[string]ResponseEntity{
+					"200": {Description: "on", Schema: &SchemaEntity{Ref: "#/definitions/Pet"}},
+				},
+			}},
+		},
+		Definitions: map[string]SchemaEntity{
+			"Pet": {
+				Type: "object",
+				Properties: map[string]*SchemaEntity{
+					"status": {Type: "string", Enum: []interface{}{"yes", "no", "~", "1e3", nil}},
+					"weight": {Type: "number", Example: 1.5, Default: 1e21},
+					"count":  {Type: "integer", Example: 3},
+					"tame":   {Type: "boolean", Example: true},
+				},
+			},
+		},
+	}
+	out, err := ToYAML(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromYAML interface{}
+	if err := yaml.Unmarshal(out, &fromYAML); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON interface{}
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	// YAML decodes whole numbers as ints, JSON as float64.
+	if !reflect.DeepEqual(normalizeNumbers(fromYAML), normalizeNumbers(fromJSON)) {
+		t.Errorf("YAML round trip differs from the JSON encoding:\n%s", out)
+	}
+	if !strings.HasPrefix(string(out), "swagger: \"2.0\"\ninfo:\n  version: \"1.0\"\n  title: Pets\nbasePath: /v2\n") {
+		t.Errorf("keys are not in struct tag order:\n%s", out)
+	}
+}
+
+// normalizeNumbers converts every number of a decoded document to float64.
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+	case int:
+		return float64(v)
+	}
+	return v
+}
+
+func TestMarshalYAML(t *testing.T) {
+	doc := SwaggerDocEntity{Swagger: "2.0", Info: InfoEntity{Title: "yes", Version: "1"}, Paths: map[string]PathItemEntity{}}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "swagger: \"2.0\"\ninfo:\n    version: \"1\"\n    title: \"yes\"\npaths: {}\n"
+	if string(out) != want {
+		t.Errorf("yaml.Marshal = %q, want %q", out, want)
+	}
+}
@@ -0,0 +1,35 @@
+package reconcile
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+)
+
+// Gin compares the routes of a gin engine, as returned by engine.Routes(),
+// against the operations of doc. Route paths are translated from gin syntax
+// and the document's BasePath is stripped before matching. Routes outside
+// the BasePath are undocumented.
+func Gin(routes gin.RoutesInfo, doc openapi_spec.SwaggerDocEntity) Report {
+	var inside, outside gin.RoutesInfo
+	for _, route := range routes {
+		if insideBasePath(route.Path, doc.BasePath) {
+			inside = append(inside, route)
+		} else {
+			outside = append(outside, route)
+		}
+	}
+	return Routes(GinRoutes(inside, doc.BasePath), doc).withUndocumented(GinRoutes(outside, ""))
+}
+
+// GinRoutes converts gin routes into Swagger template routes relative to basePath.
+func GinRoutes(routes gin.RoutesInfo, basePath string) []Route {
+	result := make([]Route, 0, len(routes))
+	for _, route := range routes {
+		result = append(result, Route{
+			Method: route.Method,
+			Path:   swagger.GinPathToTemplate(route.Path, basePath),
+		})
+	}
+	return result
+}
@@ -0,0 +1,225 @@
+package reconcile
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// Route is an HTTP method and a path in Swagger template syntax
+// ("/pet/{petId}"), relative to the document's BasePath.
+type Route struct {
+	Method string
+	Path   string
+}
+
+func (r Route) String() string {
+	return r.Method + " " + r.Path
+}
+
+// MethodMismatch is a path known to both the router and the document whose
+// methods differ between them.
+type MethodMismatch struct {
+	Path       string
+	Registered []string // methods with a handler but no documentation
+	Documented []string // methods documented but without a handler
+}
+
+// Report lists the differences between registered routes and documented
+// operations.
+type Report struct {
+	Undocumented     []Route          // registered routes missing from the document
+	Unimplemented    []Route          // documented operations without a handler
+	MethodMismatches []MethodMismatch // paths present in both with different methods
+}
+
+// OK reports whether routes and document match.
+func (r Report) OK() bool {
+	return len(r.Undocumented) == 0 && len(r.Unimplemented) == 0 && len(r.MethodMismatches) == 0
+}
+
+// Err returns nil when the report is clean and an error describing every
+// difference otherwise, so it can be used as a startup check:
+//
+//	if err := reconcile.Gin(router.Routes(), swagger.Swagger().Build()).Err(); err != nil {
+//		log.Fatal(err)
+//	}
+func (r Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return fmt.Errorf("routes and documentation differ:\n%s", r.String())
+}
+
+func (r Report) String() string {
+	var sb strings.Builder
+	for _, route := range r.Undocumented {
+		fmt.Fprintf(&sb, "undocumented route: %s\n", route)
+	}
+	for _, route := range r.Unimplemented {
+		fmt.Fprintf(&sb, "documented operation without handler: %s\n", route)
+	}
+	for _, mismatch := range r.MethodMismatches {
+		fmt.Fprintf(&sb, "method mismatch on %s: registered %v, documented %v\n",
+			mismatch.Path, mismatch.Registered, mismatch.Documented)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Without drops every entry for the given paths, e.g. health checks that are
+// intentionally left out of the document. Paths use Swagger template syntax.
+func (r Report) Without(paths ...string) Report {
+	skip := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		skip[pathKey(path)] = true
+	}
+	filtered := Report{}
+	for _, route := range r.Undocumented {
+		if !skip[pathKey(route.Path)] {
+			filtered.Undocumented = append(filtered.Undocumented, route)
+		}
+	}
+	for _, route := range r.Unimplemented {
+		if !skip[pathKey(route.Path)] {
+			filtered.Unimplemented = append(filtered.Unimplemented, route)
+		}
+	}
+	for _, mismatch := range r.MethodMismatches {
+		if !skip[pathKey(mismatch.Path)] {
+			filtered.MethodMismatches = append(filtered.MethodMismatches, mismatch)
+		}
+	}
+	return filtered
+}
+
+// Routes compares registered routes against the operations of doc. Paths are
+// matched by shape, so "/pet/{id}" and "/pet/{petId}" are the same path.
+func Routes(routes []Route, doc openapi_spec.SwaggerDocEntity) Report {
+	registered := make(map[string]map[string]Route)
+	for _, route := range routes {
+		key := pathKey(route.Path)
+		if registered[key] == nil {
+			registered[key] = make(map[string]Route)
+		}
+		registered[key][strings.ToUpper(route.Method)] = route
+	}
+
+	documented := make(map[string]map[string]Route)
+	for path, item := range doc.Paths {
+		key := pathKey(path)
+		for _, method := range documentedMethods(item) {
+			if documented[key] == nil {
+				documented[key] = make(map[string]Route)
+			}
+			documented[key][method] = Route{Method: method, Path: path}
+		}
+	}
+
+	report := Report{}
+	for key, methods := range registered {
+		docMethods, known := documented[key]
+		if !known {
+			for _, route := range methods {
+				report.Undocumented = append(report.Undocumented, route)
+			}
+			continue
+		}
+		mismatch := MethodMismatch{}
+		for method, route := range methods {
+			if _, ok := docMethods[method]; !ok {
+				mismatch.Path = route.Path
+				mismatch.Registered = append(mismatch.Registered, method)
+			}
+		}
+		for method, route := range docMethods {
+			if _, ok := methods[method]; !ok {
+				mismatch.Path = route.Path
+				mismatch.Documented = append(mismatch.Documented, method)
+			}
+		}
+		if mismatch.Path != "" {
+			sort.Strings(mismatch.Registered)
+			sort.Strings(mismatch.Documented)
+			report.MethodMismatches = append(report.MethodMismatches, mismatch)
+		}
+	}
+	for key, methods := range documented {
+		if _, known := registered[key]; known {
+			continue
+		}
+		for _, route := range methods {
+			report.Unimplemented = append(report.Unimplemented, route)
+		}
+	}
+
+	sortRoutes(report.Undocumented)
+	sortRoutes(report.Unimplemented)
+	sort.Slice(report.MethodMismatches, func(i, j int) bool {
+		return report.MethodMismatches[i].Path < report.MethodMismatches[j].Path
+	})
+	return report
+}
+
+// withUndocumented adds routes, e.g. the ones outside the BasePath, to the
+// undocumented routes of r.
+func (r Report) withUndocumented(routes []Route) Report {
+	if len(routes) == 0 {
+		return r
+	}
+	r.Undocumented = append(r.Undocumented, routes...)
+	sortRoutes(r.Undocumented)
+	return r
+}
+
+// insideBasePath reports whether the full route path is served under
+// basePath, where the document places its paths.
+func insideBasePath(path, basePath string) bool {
+	basePath = strings.TrimSuffix(basePath, "/")
+	return basePath == "" || path == basePath || strings.HasPrefix(path, basePath+"/")
+}
+
+func documentedMethods(item openapi_spec.PathItemEntity) []string {
+	var methods []string
+	operations := map[string]*openapi_spec.OperationEntity{
+		http.MethodGet:     item.Get,
+		http.MethodPost:    item.Post,
+		http.MethodPut:     item.Put,
+		http.MethodDelete:  item.Delete,
+		http.MethodOptions: item.Options,
+		http.MethodHead:    item.Head,
+		http.MethodPatch:   item.Patch,
+	}
+	for method, op := range operations {
+		if op != nil {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// pathKey reduces a path template to its shape by dropping parameter names.
+func pathKey(path string) string {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = "{}"
+		}
+	}
+	key := strings.Join(segments, "/")
+	if key == "" {
+		return "/"
+	}
+	return key
+}
+
+func sortRoutes(routes []Route) {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+}
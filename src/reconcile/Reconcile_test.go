@@ -0,0 +1,241 @@
+package reconcile
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+func op() *openapi_spec.OperationEntity { return &openapi_spec.OperationEntity{} }
+
+// petDoc documents the pets of a store under basePath.
+func petDoc(basePath string) openapi_spec.SwaggerDocEntity {
+	return openapi_spec.SwaggerDocEntity{
+		BasePath: basePath,
+		Paths: map[string]openapi_spec.PathItemEntity{
+			"/pets":          {Get: op(), Post: op()},
+			"/pets/{petId}":  {Get: op(), Head: op(), Delete: op()},
+			"/owners/{id}":   {Get: op()},
+			"/files/{path}":  {Get: op()},
+			"/store/{order}": {Put: op()},
+		},
+	}
+}
+
+func ginRoutes(routes ...string) gin.RoutesInfo {
+	var info gin.RoutesInfo
+	for i := 0; i < len(routes); i += 2 {
+		info = append(info, gin.RouteInfo{Method: routes[i], Path: routes[i+1]})
+	}
+	return info
+}
+
+func TestGin(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		routes   gin.RoutesInfo
+		want     Report
+	}{
+		{
+			name:     "matching",
+			basePath: "/v2",
+			routes: ginRoutes(
+				"GET", "/v2/pets", "POST", "/v2/pets",
+				"GET", "/v2/pets/:id", "HEAD", "/v2/pets/:id", "DELETE", "/v2/pets/:id",
+				"GET", "/v2/owners/:ownerId", "GET", "/v2/files/*path", "PUT", "/v2/store/:order",
+			),
+		},
+		{
+			name:     "missing and extra",
+			basePath: "/v2",
+			routes: ginRoutes(
+				"GET", "/v2/pets", "POST", "/v2/pets",
+				"GET", "/v2/pets/:id", "HEAD", "/v2/pets/:id", "DELETE", "/v2/pets/:id",
+				"GET", "/v2/health", "GET", "/metrics", "GET", "/v2/owners/:id/pets",
+			),
+			want: Report{
+				Undocumented: []Route{{"GET", "/health"}, {"GET", "/metrics"}, {"GET", "/owners/{id}/pets"}},
+				Unimplemented: []Route{
+					{"GET", "/files/{path}"},
+					{"GET", "/owners/{id}"},
+					{"PUT", "/store/{order}"},
+				},
+			},
+		},
+		{
+			name:     "method mismatch",
+			basePath: "",
+			routes: ginRoutes(
+				"GET", "/pets", "PATCH", "/pets",
+				"GET", "/pets/:petId", "PUT", "/pets/:petId",
+				"GET", "/owners/:id", "GET", "/files/*path", "PUT", "/store/:order",
+			),
+			want: Report{MethodMismatches: []MethodMismatch{
+				{Path: "/pets", Registered: []string{"PATCH"}, Documented: []string{"POST"}},
+				{Path: "/pets/{petId}", Registered: []string{"PUT"}, Documented: []string{"DELETE", "HEAD"}},
+			}},
+		},
+		{
+			name:     "routes outside the base path",
+			basePath: "/v2",
+			routes:   ginRoutes("GET", "/pets", "POST", "/pets"),
+			want: Report{
+				Undocumented: []Route{{"GET", "/pets"}, {"POST", "/pets"}},
+				Unimplemented: []Route{
+					{"GET", "/files/{path}"},
+					{"GET", "/owners/{id}"},
+					{"GET", "/pets"},
+					{"POST", "/pets"},
+					{"DELETE", "/pets/{petId}"},
+					{"GET", "/pets/{petId}"},
+					{"HEAD", "/pets/{petId}"},
+					{"PUT", "/store/{order}"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Gin(tt.routes, petDoc(tt.basePath))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+			if got.OK() != (tt.want.String() == "") {
+				t.Errorf("OK() = %v for\n%s", got.OK(), got)
+			}
+		})
+	}
+}
+
+func TestGinEngine(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := func(c *gin.Context) {}
+	api := router.Group("/v2")
+	api.GET("/pets", handler)
+	api.POST("/pets", handler)
+	pets := api.Group("/pets/:id")
+	pets.GET("", handler)
+	pets.HEAD("", handler)
+	pets.DELETE("", handler)
+	api.GET("/owners/:id", handler)
+	api.GET("/files/*path", handler)
+	api.PUT("/store/:order", handler)
+	if err := Gin(router.Routes(), petDoc("/v2")).Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestServeMux(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		patterns []string
+		want     Report
+	}{
+		{
+			name:     "matching",
+			basePath: "/v2",
+			patterns: []string{
+				"GET /v2/pets", "POST /v2/pets",
+				// GET also serves HEAD
+				"GET /v2/pets/{id}", "DELETE /v2/pets/{id}",
+				// no method stands for every documented one
+				"/v2/owners/{id}", "GET /v2/files/{path...}", "PUT example.com/v2/store/{order}",
+			},
+		},
+		{
+			name:     "missing and extra",
+			basePath: "/v2",
+			patterns: []string{
+				"GET /v2/pets", "POST /v2/pets", "/v2/pets/{id}",
+				"GET /v2/health", "/v2/debug/", "GET /v2/owners/{id}/pets",
+			},
+			want: Report{
+				Undocumented: []Route{{"*", "/debug/"}, {"GET", "/health"}, {"GET", "/owners/{id}/pets"}},
+				Unimplemented: []Route{
+					{"GET", "/files/{path}"},
+					{"GET", "/owners/{id}"},
+					{"PUT", "/store/{order}"},
+				},
+			},
+		},
+		{
+			name: "method mismatch",
+			patterns: []string{
+				"GET /pets", "PATCH /pets",
+				"POST /pets/{petId}", "DELETE /pets/{petId}",
+				"/owners/{id}", "GET /files/{path...}", "POST /store/{order}",
+			},
+			want: Report{MethodMismatches: []MethodMismatch{
+				{Path: "/pets", Registered: []string{"PATCH"}, Documented: []string{"POST"}},
+				{Path: "/pets/{petId}", Registered: []string{"POST"}, Documented: []string{"GET", "HEAD"}},
+				{Path: "/store/{order}", Registered: []string{"POST"}, Documented: []string{"PUT"}},
+			}},
+		},
+		{
+			name:     "patterns outside the base path",
+			basePath: "/v2",
+			patterns: []string{"GET /pets", "/pets/{id}", "/v2"},
+			want: Report{
+				Undocumented: []Route{{"*", "/"}, {"GET", "/pets"}, {"*", "/pets/{id}"}},
+				Unimplemented: []Route{
+					{"GET", "/files/{path}"},
+					{"GET", "/owners/{id}"},
+					{"GET", "/pets"},
+					{"POST", "/pets"},
+					{"DELETE", "/pets/{petId}"},
+					{"GET", "/pets/{petId}"},
+					{"HEAD", "/pets/{petId}"},
+					{"PUT", "/store/{order}"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ServeMux(tt.patterns, petDoc(tt.basePath))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMux(t *testing.T) {
+	mux := NewMux()
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	mux.HandleFunc("POST /pets", handler)
+	mux.HandleFunc("GET /pets", handler)
+	mux.Handle("/pets/{id}", http.HandlerFunc(handler))
+	want := []string{"/pets/{id}", "GET /pets", "POST /pets"}
+	if got := mux.Patterns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Patterns() = %q, want %q", got, want)
+	}
+	doc := openapi_spec.SwaggerDocEntity{Paths: map[string]openapi_spec.PathItemEntity{
+		"/pets":         {Get: op(), Post: op()},
+		"/pets/{petId}": {Get: op(), Delete: op()},
+	}}
+	if err := ServeMux(mux.Patterns(), doc).Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReportWithout(t *testing.T) {
+	report := Gin(ginRoutes("GET", "/health", "GET", "/pets/:id", "PATCH", "/pets"), petDoc(""))
+	got := report.Without("/health", "/pets/{x}", "/files/{path}", "/owners/{id}", "/store/{order}")
+	want := Report{MethodMismatches: []MethodMismatch{
+		{Path: "/pets", Registered: []string{"PATCH"}, Documented: []string{"GET", "POST"}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	wantErr := "routes and documentation differ:\nmethod mismatch on /pets: registered [PATCH], documented [GET POST]"
+	if err := got.Err(); err == nil || err.Error() != wantErr {
+		t.Errorf("Err() = %v, want %q", err, wantErr)
+	}
+}
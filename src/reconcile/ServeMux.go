@@ -0,0 +1,104 @@
+package reconcile
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+)
+
+// ServeMux compares Go 1.22 http.ServeMux patterns, e.g. the ones recorded by
+// a Mux, against the operations of doc. Patterns without a method stand for
+// every method documented on their path, and GET patterns also cover a
+// documented HEAD, as they do in ServeMux. Patterns outside the BasePath are
+// undocumented.
+func ServeMux(patterns []string, doc openapi_spec.SwaggerDocEntity) Report {
+	documented := make(map[string][]string)
+	for path, item := range doc.Paths {
+		key := pathKey(path)
+		documented[key] = append(documented[key], documentedMethods(item)...)
+	}
+
+	var routes, outside []Route
+	for _, route := range ServeMuxRoutes(patterns, "") {
+		if !insideBasePath(route.Path, doc.BasePath) {
+			if route.Method == "" {
+				route.Method = "*"
+			}
+			outside = append(outside, route)
+			continue
+		}
+		route.Path = swagger.StripBasePath(route.Path, doc.BasePath)
+		methods := documented[pathKey(route.Path)]
+		switch {
+		case route.Method == "" && len(methods) == 0:
+			routes = append(routes, Route{Method: "*", Path: route.Path})
+		case route.Method == "":
+			for _, method := range methods {
+				routes = append(routes, Route{Method: method, Path: route.Path})
+			}
+		default:
+			routes = append(routes, route)
+			if route.Method == http.MethodGet && contains(methods, http.MethodHead) {
+				routes = append(routes, Route{Method: http.MethodHead, Path: route.Path})
+			}
+		}
+	}
+	return Routes(routes, doc).withUndocumented(outside)
+}
+
+// ServeMuxRoutes converts ServeMux patterns into Swagger template routes
+// relative to basePath. Routes of patterns without a method have no Method.
+func ServeMuxRoutes(patterns []string, basePath string) []Route {
+	result := make([]Route, 0, len(patterns))
+	for _, pattern := range patterns {
+		method, path := swagger.FromServeMuxPattern(pattern)
+		result = append(result, Route{
+			Method: strings.ToUpper(method),
+			Path:   swagger.StripBasePath(path, basePath),
+		})
+	}
+	return result
+}
+
+// Mux is an http.ServeMux that records the patterns it is given, since
+// ServeMux cannot list them.
+type Mux struct {
+	*http.ServeMux
+	patterns []string
+}
+
+// NewMux returns an empty Mux.
+func NewMux() *Mux {
+	return &Mux{ServeMux: http.NewServeMux()}
+}
+
+// Handle registers handler for pattern, see http.ServeMux.Handle.
+func (m *Mux) Handle(pattern string, handler http.Handler) {
+	m.ServeMux.Handle(pattern, handler)
+	m.patterns = append(m.patterns, pattern)
+}
+
+// HandleFunc registers handler for pattern, see http.ServeMux.HandleFunc.
+func (m *Mux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.ServeMux.HandleFunc(pattern, handler)
+	m.patterns = append(m.patterns, pattern)
+}
+
+// Patterns returns the registered patterns, sorted.
+func (m *Mux) Patterns() []string {
+	patterns := append([]string(nil), m.patterns...)
+	sort.Strings(patterns)
+	return patterns
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,319 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// bindingRules returns the validator rules of a struct field, read from gin's
+// "binding" tag or, when absent, from go-playground's "validate" tag.
+func bindingRules(field reflect.StructField) []string {
+	tag, ok := field.Tag.Lookup("binding")
+	if !ok {
+		tag = field.Tag.Get("validate")
+	}
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+// bindingRequired reports whether the rules make the field required. The
+// second result is false when the rules say nothing about it, in which case
+// the json "omitempty" option decides.
+func bindingRequired(rules []string) (required bool, decided bool) {
+	for _, rule := range rules {
+		switch rule {
+		case "required":
+			return true, true
+		case "omitempty":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// applyBindingRules copies the constraints gin enforces through the field's
+// validator rules onto its schema. Rules following "dive" apply to the items
+// of a slice or the values of a map. Rules that have no Swagger equivalent,
+// such as cross-field and "|" alternatives, are ignored; rules whose parameter
+// does not fit the field, such as "min=x" or a oneof value that is not a
+// number on a numeric field, are returned as errors.
+func applyBindingRules(schema *entity2.SchemaEntity, t reflect.Type, rules []string) []error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if schema == nil || schema.Ref != "" {
+		return nil
+	}
+	var errs []error
+	for i, rule := range rules {
+		if rule == "dive" {
+			rest := rules[i+1:]
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array:
+				errs = append(errs, applyBindingRules(schema.Items, t.Elem(), rest)...)
+			case reflect.Map:
+				if values, ok := schema.AdditionalProperties.(*entity2.SchemaEntity); ok {
+					errs = append(errs, applyBindingRules(values, t.Elem(), skipKeyRules(rest))...)
+				}
+			default:
+				errs = append(errs, fmt.Errorf("binding rule %q applies to slices, arrays and maps, not %s", rule, t.Kind()))
+			}
+			return errs
+		}
+		if strings.Contains(rule, "|") {
+			continue
+		}
+		name, param, _ := strings.Cut(rule, "=")
+		param = unescapeBindingParam(param)
+		if err := applyBindingRule(schema, t, name, param); err != nil {
+			errs = append(errs, fmt.Errorf("binding rule %q: %w", rule, err))
+		}
+	}
+	return errs
+}
+
+func applyBindingRule(schema *entity2.SchemaEntity, t reflect.Type, name, param string) error {
+	switch name {
+	case "min", "gte":
+		return setLowerBound(schema, t, param, false)
+	case "max", "lte":
+		return setUpperBound(schema, t, param, false)
+	case "gt":
+		return setLowerBound(schema, t, param, true)
+	case "lt":
+		return setUpperBound(schema, t, param, true)
+	case "len":
+		if err := setLowerBound(schema, t, param, false); err != nil {
+			return err
+		}
+		return setUpperBound(schema, t, param, false)
+	case "oneof":
+		var enum []interface{}
+		for _, value := range splitOneOf(param) {
+			parsed, err := parseTagValue(t, value)
+			if err != nil {
+				return err
+			}
+			enum = append(enum, parsed)
+		}
+		restrictEnum(schema, enum)
+	case "unique":
+		if schema.Type == "array" {
+			schema.UniqueItems = true
+		}
+	case "email":
+		schema.Format = "email"
+	case "uuid", "uuid3", "uuid4", "uuid5", "uuid_rfc4122", "uuid3_rfc4122", "uuid4_rfc4122", "uuid5_rfc4122":
+		schema.Format = "uuid"
+	case "url", "http_url", "uri":
+		schema.Format = "uri"
+	case "hostname", "hostname_rfc1123":
+		schema.Format = "hostname"
+	case "ipv4", "ip4_addr":
+		schema.Format = "ipv4"
+	case "ipv6", "ip6_addr":
+		schema.Format = "ipv6"
+	case "datetime":
+		if param == time.RFC3339 {
+			schema.Format = "date-time"
+		} else if param == "2006-01-02" {
+			schema.Format = "date"
+		}
+	case "alpha":
+		schema.Pattern = "^[a-zA-Z]+$"
+	case "alphanum":
+		schema.Pattern = "^[a-zA-Z0-9]+$"
+	case "numeric":
+		schema.Pattern = `^[-+]?[0-9]+(?:\.[0-9]+)?$`
+	case "number":
+		schema.Pattern = "^[0-9]+$"
+	case "hexadecimal":
+		schema.Pattern = "^(0[xX])?[0-9a-fA-F]+$"
+	case "lowercase":
+		schema.Pattern = "^[^A-Z]*$"
+	case "uppercase":
+		schema.Pattern = "^[^a-z]*$"
+	case "e164":
+		schema.Pattern = `^\+[1-9]?[0-9]{7,14}$`
+	case "startswith":
+		schema.Pattern = "^" + regexp.QuoteMeta(param)
+	case "endswith":
+		schema.Pattern = regexp.QuoteMeta(param) + "$"
+	case "contains":
+		schema.Pattern = regexp.QuoteMeta(param)
+	}
+	return nil
+}
+
+// setLowerBound maps min/gte/gt onto the bound matching the field's kind:
+// length for strings, item count for slices, property count for maps and
+// value for numbers. Other kinds, such as time.Time, have no equivalent.
+func setLowerBound(schema *entity2.SchemaEntity, t reflect.Type, param string, exclusive bool) error {
+	switch {
+	case countKind(t):
+		count, err := countBound(param, exclusive, 1)
+		if err != nil {
+			return err
+		}
+		switch t.Kind() {
+		case reflect.String:
+			schema.MinLength = &count
+		case reflect.Slice, reflect.Array:
+			schema.MinItems = &count
+		case reflect.Map:
+			schema.MinProperties = &count
+		}
+	case numericKind(t):
+		value, err := numericBound(t, param)
+		if err != nil {
+			return err
+		}
+		schema.Minimum = &value
+		schema.ExclusiveMinimum = exclusive
+	}
+	return nil
+}
+
+func setUpperBound(schema *entity2.SchemaEntity, t reflect.Type, param string, exclusive bool) error {
+	switch {
+	case countKind(t):
+		count, err := countBound(param, exclusive, -1)
+		if err != nil {
+			return err
+		}
+		switch t.Kind() {
+		case reflect.String:
+			schema.MaxLength = &count
+		case reflect.Slice, reflect.Array:
+			schema.MaxItems = &count
+		case reflect.Map:
+			schema.MaxProperties = &count
+		}
+	case numericKind(t):
+		value, err := numericBound(t, param)
+		if err != nil {
+			return err
+		}
+		schema.Maximum = &value
+		schema.ExclusiveMaximum = exclusive
+	}
+	return nil
+}
+
+func countKind(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+func numericKind(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// countBound parses a length bound; exclusive bounds are shifted by step since
+// lengths are whole numbers.
+func countBound(param string, exclusive bool, step int) (int, error) {
+	count, err := strconv.Atoi(param)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("%q is not a valid length", param)
+	}
+	if exclusive {
+		count += step
+	}
+	return count, nil
+}
+
+// numericBound parses a value bound; time.Duration bounds may also be written
+// as durations, "min=1s", like validator accepts them.
+func numericBound(t reflect.Type, param string) (float64, error) {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		if d, err := time.ParseDuration(param); err == nil {
+			return float64(d), nil
+		}
+	}
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", param)
+	}
+	return value, nil
+}
+
+// parseTagValue converts a tag value into the Go kind of t, so enum values and
+// examples of numeric fields are rendered as numbers rather than strings.
+func parseTagValue(t reflect.Type, s string) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var value interface{}
+	var err error
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err = strconv.ParseInt(s, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err = strconv.ParseUint(s, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		value, err = strconv.ParseFloat(s, t.Bits())
+	case reflect.Bool:
+		value, err = strconv.ParseBool(s)
+	default:
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid %s value", s, t.Kind())
+	}
+	return value, nil
+}
+
+// splitOneOf splits a oneof parameter on spaces, honouring single-quoted
+// values that contain spaces: "'new york' paris".
+func splitOneOf(param string) []string {
+	var values []string
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if param[0] == '\'' {
+			if end := strings.IndexByte(param[1:], '\''); end >= 0 {
+				values = append(values, param[1:end+1])
+				param = param[end+2:]
+				continue
+			}
+		}
+		value, rest, _ := strings.Cut(param, " ")
+		values = append(values, value)
+		param = rest
+	}
+	return values
+}
+
+// skipKeyRules drops a "keys ... endkeys" block, which validates map keys.
+func skipKeyRules(rules []string) []string {
+	if len(rules) == 0 || rules[0] != "keys" {
+		return rules
+	}
+	for i, rule := range rules {
+		if rule == "endkeys" {
+			return rules[i+1:]
+		}
+	}
+	return nil
+}
+
+// unescapeBindingParam decodes the escapes validator uses for separators
+// inside parameters.
+func unescapeBindingParam(param string) string {
+	return strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(param)
+}
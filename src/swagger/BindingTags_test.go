@@ -0,0 +1,156 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// boundRules returns the JSON of the schema of t after applying the rules of
+// a binding tag, and the errors they raised.
+func boundRules(t *testing.T, typ reflect.Type, tag string) (string, []string) {
+	t.Helper()
+	schema, err := New().GenerateSchemaFromGoType(typ, make(map[string]bool))
+	if err != nil {
+		t.Fatal(err)
+	}
+	field := reflect.StructField{Name: "F", Type: typ, Tag: reflect.StructTag(`binding:"` + tag + `"`)}
+	var errs []string
+	for _, err := range applyBindingRules(schema, typ, bindingRules(field)) {
+		errs = append(errs, err.Error())
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), errs
+}
+
+// sameJSON reports whether two JSON documents hold the same values,
+// regardless of the order of their keys.
+func sameJSON(a, b string) bool {
+	var x, y interface{}
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func TestBindingRules(t *testing.T) {
+	str := reflect.TypeFor[string]()
+	integer := reflect.TypeFor[int]()
+	tests := []struct {
+		typ  reflect.Type
+		tag  string
+		want string
+	}{
+		{str, "required", `{"type":"string"}`},
+		{str, "min=1", `{"type":"string","minLength":1}`},
+		{str, "max=10", `{"type":"string","maxLength":10}`},
+		{str, "gte=1,lte=10", `{"type":"string","maxLength":10,"minLength":1}`},
+		{str, "gt=1,lt=10", `{"type":"string","maxLength":9,"minLength":2}`},
+		{str, "len=4", `{"type":"string","maxLength":4,"minLength":4}`},
+		{integer, "min=1,max=10", `{"type":"integer","format":"int32","maximum":10,"minimum":1}`},
+		{integer, "gt=0,lt=10", `{"type":"integer","format":"int32","maximum":10,"exclusiveMaximum":true,"minimum":0,"exclusiveMinimum":true}`},
+		{reflect.TypeFor[float64](), "gte=0.5", `{"type":"number","format":"double","minimum":0.5}`},
+		{reflect.TypeFor[time.Duration](), "min=1s", `{"type":"integer","format":"int64","minimum":1000000000}`},
+		{reflect.TypeFor[[]string](), "min=1,max=3,unique", `{"type":"array","items":{"type":"string"},"maxItems":3,"minItems":1,"uniqueItems":true}`},
+		{reflect.TypeFor[map[string]int](), "min=1", `{"type":"object","minProperties":1,"additionalProperties":{"type":"integer","format":"int32"}}`},
+		{str, "oneof=red green 'dark blue'", `{"type":"string","enum":["red","green","dark blue"]}`},
+		{integer, "oneof=1 2 3", `{"type":"integer","format":"int32","enum":[1,2,3]}`},
+		{reflect.TypeFor[bool](), "oneof=true", `{"type":"boolean","enum":[true]}`},
+		{str, "email", `{"type":"string","format":"email"}`},
+		{str, "uuid4", `{"type":"string","format":"uuid"}`},
+		{str, "url", `{"type":"string","format":"uri"}`},
+		{str, "hostname", `{"type":"string","format":"hostname"}`},
+		{str, "ipv4", `{"type":"string","format":"ipv4"}`},
+		{str, "ipv6", `{"type":"string","format":"ipv6"}`},
+		{str, "datetime=2006-01-02T15:04:05Z07:00", `{"type":"string","format":"date-time"}`},
+		{str, "datetime=2006-01-02", `{"type":"string","format":"date"}`},
+		{str, "datetime=02/01/2006", `{"type":"string"}`},
+		{str, "alpha", `{"type":"string","pattern":"^[a-zA-Z]+$"}`},
+		{str, "alphanum", `{"type":"string","pattern":"^[a-zA-Z0-9]+$"}`},
+		{str, "numeric", `{"type":"string","pattern":"^[-+]?[0-9]+(?:\\.[0-9]+)?$"}`},
+		{str, "number", `{"type":"string","pattern":"^[0-9]+$"}`},
+		{str, "hexadecimal", `{"type":"string","pattern":"^(0[xX])?[0-9a-fA-F]+$"}`},
+		{str, "lowercase", `{"type":"string","pattern":"^[^A-Z]*$"}`},
+		{str, "uppercase", `{"type":"string","pattern":"^[^a-z]*$"}`},
+		{str, "e164", `{"type":"string","pattern":"^\\+[1-9]?[0-9]{7,14}$"}`},
+		{str, "startswith=a.b", `{"type":"string","pattern":"^a\\.b"}`},
+		{str, "endswith=0x2C", `{"type":"string","pattern":",$"}`},
+		{str, "contains=x", `{"type":"string","pattern":"x"}`},
+		{str, "email|url,min=1", `{"type":"string","minLength":1}`},
+		{str, "eqfield=Other,custom", `{"type":"string"}`},
+		{reflect.TypeFor[[]string](), "min=1,dive,email", `{"type":"array","items":{"type":"string","format":"email"},"minItems":1}`},
+		{reflect.TypeFor[map[string]string](), "dive,keys,alpha,endkeys,max=5", `{"type":"object","additionalProperties":{"type":"string","maxLength":5}}`},
+		{reflect.TypeFor[*string](), "omitempty,min=2", `{"type":"string","minLength":2}`},
+		{reflect.TypeFor[time.Time](), "gt", `{"type":"string","format":"date-time"}`},
+	}
+	for _, tt := range tests {
+		got, errs := boundRules(t, tt.typ, tt.tag)
+		if !sameJSON(got, tt.want) {
+			t.Errorf("%s %q: %s, want %s", tt.typ, tt.tag, got, tt.want)
+		}
+		if len(errs) > 0 {
+			t.Errorf("%s %q: unexpected errors %q", tt.typ, tt.tag, errs)
+		}
+	}
+}
+
+func TestMalformedBindingRules(t *testing.T) {
+	str := reflect.TypeFor[string]()
+	integer := reflect.TypeFor[int]()
+	tests := []struct {
+		typ  reflect.Type
+		tag  string
+		want []string
+	}{
+		{str, "min=x", []string{`binding rule "min=x": "x" is not a valid length`}},
+		{str, "max=-1", []string{`binding rule "max=-1": "-1" is not a valid length`}},
+		{str, "len", []string{`binding rule "len": "" is not a valid length`}},
+		{integer, "max=ten", []string{`binding rule "max=ten": "ten" is not a number`}},
+		{integer, "gt=1,lt=", []string{`binding rule "lt=": "" is not a number`}},
+		{integer, "oneof=1 two 3", []string{`binding rule "oneof=1 two 3": "two" is not a valid int value`}},
+		{reflect.TypeFor[bool](), "oneof=yes", []string{`binding rule "oneof=yes": "yes" is not a valid bool value`}},
+		{str, "dive,min=1", []string{`binding rule "dive" applies to slices, arrays and maps, not string`}},
+		{reflect.TypeFor[[]int](), "dive,min=a", []string{`binding rule "min=a": "a" is not a number`}},
+	}
+	for _, tt := range tests {
+		_, errs := boundRules(t, tt.typ, tt.tag)
+		if !reflect.DeepEqual(errs, tt.want) {
+			t.Errorf("%s %q: errors %q, want %q", tt.typ, tt.tag, errs, tt.want)
+		}
+	}
+}
+
+type malformedBinding struct {
+	Name string `json:"name" binding:"required,min=x"`
+	Age  int    `json:"age" binding:"oneof=young old"`
+}
+
+func TestMalformedBindingRulesReported(t *testing.T) {
+	doc := New()
+	if _, err := doc.SchemaFromType(reflect.TypeFor[malformedBinding]()); err != nil {
+		t.Fatal(err)
+	}
+	var errs []string
+	for _, err := range doc.Errors() {
+		errs = append(errs, err.Error())
+	}
+	want := []string{
+		`definitions.malformedBinding field Name: binding rule "min=x": "x" is not a valid length`,
+		`definitions.malformedBinding field Age: binding rule "oneof=young old": "young" is not a valid int value`,
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors %q, want %q", errs, want)
+	}
+	schema := doc.Build().Definitions["malformedBinding"]
+	if schema.Properties["name"].MinLength != nil || schema.Properties["age"].Enum != nil {
+		t.Errorf("malformed rules were applied: %+v", schema.Properties)
+	}
+	if got := strings.Join(schema.Required, ","); got != "name,age" {
+		t.Errorf("required %s, want name,age", got)
+	}
+}
@@ -0,0 +1,632 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// DefinitionCollisions sets how definition name collisions are handled,
+// openapi.CollisionError by default.
+func (b *SwaggerDocBuilder) DefinitionCollisions(strategy openapi2.CollisionStrategy) *SwaggerDocBuilder {
+	b.definitionsMux.Lock()
+	defer b.definitionsMux.Unlock()
+	b.collisions = strategy
+	return b
+}
+
+// DefinitionNamer names the definitions of Go types with namer instead of
+// their type names, e.g. to prefix them with their package. An empty result
+// keeps the default name. It is set before DTOs are registered, so the
+// collisions between the names it returns are reported like the others.
+func (b *SwaggerDocBuilder) DefinitionNamer(namer func(t reflect.Type) string) *SwaggerDocBuilder {
+	b.definitionsMux.Lock()
+	defer b.definitionsMux.Unlock()
+	b.namer = namer
+	return b
+}
+
+// definitionNameFor returns the provisional definition name of a named
+// type, giving every type its own name. The first type met keeps the name
+// it shares with types of other packages, Build replaces it with the
+// resolved one, see resolvedNames. The caller holds definitionsMux.
+func (b *SwaggerDocBuilder) definitionNameFor(t reflect.Type) string {
+	if name, ok := b.typeNames[t]; ok {
+		return name
+	}
+	name := b.definitionName(t)
+	if b.namer != nil {
+		if custom := b.namer(t); custom != "" {
+			name = custom
+		}
+	}
+	if name == "" {
+		return ""
+	}
+	if owner, taken := b.definitionTypes[name]; taken {
+		if b.collisions == openapi2.CollisionError {
+			first, second := typeString(owner), typeString(t)
+			if second < first {
+				first, second = second, first
+			}
+			b.reportError("definitions."+name, fmt.Errorf("%s and %s have the same definition name", first, second))
+		}
+		name = b.qualifiedName(t, name)
+	}
+	b.nameType(t, name)
+	return name
+}
+
+// nameType gives t the definition name name.
+func (b *SwaggerDocBuilder) nameType(t reflect.Type, name string) {
+	if b.typeNames == nil {
+		b.typeNames = make(map[reflect.Type]string)
+		b.definitionTypes = make(map[string]reflect.Type)
+	}
+	b.typeNames[t] = name
+	b.definitionTypes[name] = t
+	if b.identifierNames == nil {
+		b.identifierNames = make(map[string]string)
+	}
+	b.identifierNames[typeString(t)] = name
+}
+
+// qualifiedName prefixes name with the last elements of the package path of
+// t, as many as needed to make it unique.
+func (b *SwaggerDocBuilder) qualifiedName(t reflect.Type, name string) string {
+	elements := strings.Split(t.PkgPath(), "/")
+	for n := 1; n <= len(elements); n++ {
+		candidate := strings.Join(elements[len(elements)-n:], ".") + "." + name
+		if _, taken := b.definitionTypes[candidate]; !taken {
+			return candidate
+		}
+	}
+	for i := 2; ; i++ {
+		candidate := name + strconv.Itoa(i)
+		if _, taken := b.definitionTypes[candidate]; !taken {
+			return candidate
+		}
+	}
+}
+
+// resolvedNames returns the final definition names of the documented Go
+// types that differ from their provisional ones. The final names only
+// depend on the set of types: a name shared by several types is qualified
+// for all of them, with as many elements of their package paths as needed
+// (and of the packages of their type arguments, for generics), falling back
+// to numbers in the order of their qualified type names. Implementations of
+// polymorphic types keep their discriminator values.
+func (b *SwaggerDocBuilder) resolvedNames() map[string]string {
+	b.definitionsMux.Lock()
+	defer b.definitionsMux.Unlock()
+
+	taken := make(map[string]bool)
+	for name := range b.doc.Definitions {
+		if _, owned := b.definitionTypes[name]; !owned { // added with Definition
+			taken[name] = true
+		}
+	}
+	resolved := make(map[reflect.Type]string)
+	defined := make(map[string]reflect.Type)
+	var types []reflect.Type
+	for t, name := range b.typeNames {
+		if _, ok := b.doc.Definitions[name]; !ok {
+			continue
+		}
+		defined[typeString(t)] = t
+		if _, ok := b.implementationOf[t]; ok {
+			resolved[t] = name
+			taken[name] = true
+			continue
+		}
+		types = append(types, t)
+	}
+	// Type arguments are named before the generic types using them
+	sort.Slice(types, func(i, j int) bool {
+		if di, dj := typeDepth(types[i]), typeDepth(types[j]); di != dj {
+			return di < dj
+		}
+		return typeString(types[i]) < typeString(types[j])
+	})
+	for start := 0; start < len(types); {
+		end := start + 1
+		for end < len(types) && typeDepth(types[end]) == typeDepth(types[start]) {
+			end++
+		}
+		var names []string
+		groups := make(map[string][]reflect.Type)
+		for _, t := range types[start:end] {
+			name := b.spelledName(t, resolved, defined, 0)
+			if groups[name] == nil {
+				names = append(names, name)
+			}
+			groups[name] = append(groups[name], t)
+		}
+		for _, name := range names {
+			b.resolveGroup(name, groups[name], resolved, defined, taken)
+		}
+		start = end
+	}
+
+	renames := make(map[string]string)
+	for t, name := range resolved {
+		if provisional := b.typeNames[t]; provisional != name {
+			renames[provisional] = name
+		}
+	}
+	return renames
+}
+
+// resolveGroup names the types sharing the name name, see resolvedNames.
+func (b *SwaggerDocBuilder) resolveGroup(name string, group []reflect.Type, resolved map[reflect.Type]string, defined map[string]reflect.Type, taken map[string]bool) {
+	assign := func(spell func(t reflect.Type) string) bool {
+		names := make(map[string]bool, len(group))
+		for _, t := range group {
+			name := spell(t)
+			if taken[name] || names[name] {
+				return false
+			}
+			names[name] = true
+		}
+		for _, t := range group {
+			resolved[t] = spell(t)
+			taken[resolved[t]] = true
+		}
+		return true
+	}
+	if assign(func(reflect.Type) string { return name }) {
+		return
+	}
+	// Qualify the types by their packages, then generics by the packages of
+	// their type arguments, then by both
+	qualified := func(t reflect.Type, n, arguments int) string {
+		name := b.spelledName(t, resolved, defined, arguments)
+		if n == 0 {
+			return name
+		}
+		return packageQualifier(t.PkgPath(), n) + "." + name
+	}
+	for n := 1; ; n++ {
+		for _, levels := range [][2]int{{n, 0}, {0, n}, {n, n}} {
+			if assign(func(t reflect.Type) string { return qualified(t, levels[0], levels[1]) }) {
+				return
+			}
+		}
+		exhausted := true
+		for _, t := range group {
+			exhausted = exhausted && qualified(t, n, n) == qualified(t, n-1, n-1)
+		}
+		if exhausted {
+			break
+		}
+	}
+	// Same packages all the way, e.g. two types a DefinitionNamer gives the
+	// same name
+	i := 1
+	for _, t := range group {
+		candidate := name
+		for taken[candidate] {
+			i++
+			candidate = name + strconv.Itoa(i)
+		}
+		resolved[t] = candidate
+		taken[candidate] = true
+	}
+}
+
+// renameDefinitions returns doc with the definitions renamed after renames,
+// and the references to them.
+func renameDefinitions(doc entity2.SwaggerDocEntity, renames map[string]string) entity2.SwaggerDocEntity {
+	definitions := make(map[string]entity2.SchemaEntity, len(doc.Definitions))
+	for name, schema := range doc.Definitions {
+		if renamed, ok := renames[name]; ok {
+			name = renamed
+		}
+		definitions[name] = *renamedRefs(&schema, renames)
+	}
+	doc.Definitions = definitions
+	doc.Paths = renamedPathRefs(doc.Paths, renames)
+	doc.Webhooks = renamedPathRefs(doc.Webhooks, renames)
+	return doc
+}
+
+// renamedRefs returns a copy of schema whose references follow renames.
+func renamedRefs(schema *entity2.SchemaEntity, renames map[string]string) *entity2.SchemaEntity {
+	if schema == nil {
+		return nil
+	}
+	renamed := *schema
+	if name, ok := strings.CutPrefix(schema.Ref, "#/definitions/"); ok {
+		if to, ok := renames[name]; ok {
+			renamed.Ref = "#/definitions/" + to
+		}
+	}
+	renamed.Items = renamedRefs(schema.Items, renames)
+	if schema.AllOf != nil {
+		renamed.AllOf = make([]*entity2.SchemaEntity, len(schema.AllOf))
+		for i, part := range schema.AllOf {
+			renamed.AllOf[i] = renamedRefs(part, renames)
+		}
+	}
+	if schema.Properties != nil {
+		renamed.Properties = make(map[string]*entity2.SchemaEntity, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			renamed.Properties[name] = renamedRefs(prop, renames)
+		}
+	}
+	switch additional := schema.AdditionalProperties.(type) {
+	case *entity2.SchemaEntity:
+		renamed.AdditionalProperties = renamedRefs(additional, renames)
+	case entity2.SchemaEntity:
+		renamed.AdditionalProperties = *renamedRefs(&additional, renames)
+	}
+	return &renamed
+}
+
+func renamedPathRefs(paths map[string]entity2.PathItemEntity, renames map[string]string) map[string]entity2.PathItemEntity {
+	if paths == nil {
+		return nil
+	}
+	parameters := func(params []entity2.ParameterEntity) []entity2.ParameterEntity {
+		if params == nil {
+			return nil
+		}
+		renamed := make([]entity2.ParameterEntity, len(params))
+		for i, param := range params {
+			param.Schema = renamedRefs(param.Schema, renames)
+			renamed[i] = param
+		}
+		return renamed
+	}
+	operation := func(op *entity2.OperationEntity) *entity2.OperationEntity {
+		if op == nil {
+			return nil
+		}
+		renamed := *op
+		renamed.Parameters = parameters(op.Parameters)
+		if op.Responses != nil {
+			renamed.Responses = make(map[string]entity2.ResponseEntity, len(op.Responses))
+			for code, response := range op.Responses {
+				response.Schema = renamedRefs(response.Schema, renames)
+				renamed.Responses[code] = response
+			}
+		}
+		return &renamed
+	}
+	result := make(map[string]entity2.PathItemEntity, len(paths))
+	for path, item := range paths {
+		item.Get = operation(item.Get)
+		item.Post = operation(item.Post)
+		item.Put = operation(item.Put)
+		item.Delete = operation(item.Delete)
+		item.Options = operation(item.Options)
+		item.Head = operation(item.Head)
+		item.Patch = operation(item.Patch)
+		item.Parameters = parameters(item.Parameters)
+		result[path] = item
+	}
+	return result
+}
+
+// spelledName returns the definition name of t whose type arguments are
+// spelled with their resolved names, or when they have no definition, by
+// their type names qualified with n elements of their package paths.
+func (b *SwaggerDocBuilder) spelledName(t reflect.Type, resolved map[reflect.Type]string, defined map[string]reflect.Type, n int) string {
+	if b.namer != nil {
+		if custom := b.namer(t); custom != "" {
+			return custom
+		}
+	}
+	name := t.Name()
+	if !strings.Contains(name, "[") {
+		return name
+	}
+	readable, _ := readableTypeName(name, func(identifier string) (string, bool) {
+		if argument, ok := defined[identifier]; ok {
+			if name, ok := resolved[argument]; ok {
+				return refName(name), true
+			}
+		}
+		pkg, name, qualified := cutLast(identifier, ".")
+		if n == 0 || !qualified || strings.Contains(identifier, "[") {
+			return "", false
+		}
+		return refName(packageQualifier(pkg, n) + "." + name), true
+	})
+	return readable
+}
+
+// packageQualifier returns the last n elements of a package path.
+func packageQualifier(pkgPath string, n int) string {
+	elements := strings.Split(pkgPath, "/")
+	if n > len(elements) {
+		n = len(elements)
+	}
+	return strings.Join(elements[len(elements)-n:], ".")
+}
+
+// typeDepth returns how deep type arguments are nested in the name of t.
+func typeDepth(t reflect.Type) int {
+	depth, deepest := 0, 0
+	for _, r := range t.Name() {
+		switch r {
+		case '[':
+			depth++
+			deepest = max(deepest, depth)
+		case ']':
+			depth--
+		}
+	}
+	return deepest
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return "", s, false
+}
+
+func typeString(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// definitionName returns the definition key of a named Go type. Plain types
+// keep their name; instantiated generics, which reflect names like
+// "Page[example.com/app/dto.User]", are spelled out without brackets so they
+// are valid in a $ref, each type argument by its own definition name:
+//
+//	Page[User]                 PageOfUser
+//	Page[*User]                PageOfNullableUser
+//	Pair[string, int]          PairOfStringAndInt
+//	Page[[]User]               PageOfUserList
+//	Page[map[string]User]      PageOfMapOfStringToUser
+//	Page[Page[User]]           PageOfPageOfUser
+//	Page[other.User]           PageOfOtherUser, when other.User is "other.User"
+//	Page[struct{ A, B int }]   PageOfObjectWithAAndB
+//
+// Type arguments without a definition, e.g. enums or arguments no field
+// uses, are spelled by their type name. Build resolves the final names from
+// all the documented types, so they do not depend on the order the types
+// are registered in, see resolvedNames.
+func (b *SwaggerDocBuilder) definitionName(t reflect.Type) string {
+	name := t.Name()
+	if !strings.Contains(name, "[") {
+		return name
+	}
+	arguments := make(map[string]reflect.Type)
+	fieldTypes(t, arguments, make(map[reflect.Type]bool))
+	readable, _ := readableTypeName(name, func(identifier string) (string, bool) {
+		if t, ok := arguments[identifier]; ok {
+			return refName(b.definitionNameFor(t)), true
+		}
+		if name, ok := b.identifierNames[identifier]; ok {
+			return refName(name), true
+		}
+		return "", false
+	})
+	return readable
+}
+
+// fieldTypes collects the named types the fields of t are built from, by
+// their qualified names. Reflection does not expose the type arguments of a
+// generic type, but its fields usually use them.
+func fieldTypes(t reflect.Type, types map[string]reflect.Type, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		collectNamedTypes(t.Field(i).Type, types, visited)
+	}
+}
+
+func collectNamedTypes(t reflect.Type, types map[string]reflect.Type, visited map[reflect.Type]bool) {
+	if t.Name() != "" {
+		if t.PkgPath() != "" {
+			types[typeString(t)] = t
+		}
+		return
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		collectNamedTypes(t.Elem(), types, visited)
+	case reflect.Map:
+		collectNamedTypes(t.Key(), types, visited)
+		collectNamedTypes(t.Elem(), types, visited)
+	case reflect.Struct:
+		fieldTypes(t, types, visited)
+	}
+}
+
+// genericBaseName returns the name of a type without its type arguments, as
+// declared in the source.
+func genericBaseName(t reflect.Type) string {
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return name
+}
+
+// typeNameResolver spells a named type, given by its qualified identifier
+// such as "example.com/app/dto.User", as part of a generic definition name.
+// It reports false to spell it by its type name.
+type typeNameResolver func(identifier string) (string, bool)
+
+// readableTypeName converts the type expression at the start of s and
+// returns the rest of s. Pointers are spelled as nullable, so Page[*User]
+// and Page[User] get different names.
+func readableTypeName(s string, resolve typeNameResolver) (string, string) {
+	switch {
+	case strings.HasPrefix(s, "*"):
+		elem, rest := readableTypeName(s[1:], resolve)
+		return "Nullable" + elem, rest
+	case strings.HasPrefix(s, "[]"):
+		elem, rest := readableTypeName(s[2:], resolve)
+		return elem + "List", rest
+	case strings.HasPrefix(s, "["): // array
+		if end := strings.Index(s, "]"); end >= 0 {
+			elem, rest := readableTypeName(s[end+1:], resolve)
+			return elem + "List", rest
+		}
+	case strings.HasPrefix(s, "map["):
+		key, rest := readableTypeName(s[len("map["):], resolve)
+		value, rest := readableTypeName(strings.TrimPrefix(rest, "]"), resolve)
+		return "MapOf" + key + "To" + value, rest
+	case strings.HasPrefix(s, "struct {"):
+		rest := skipBraces(s)
+		return "Object" + anonymousStructFields(s[:len(s)-len(rest)]), rest
+	case strings.HasPrefix(s, "interface {"):
+		return "Object", skipBraces(s)
+	}
+
+	end := strings.IndexAny(s, "[],")
+	if end < 0 {
+		end = len(s)
+	}
+	identifier, rest := s[:end], s[end:]
+	if name, ok := resolve(identifier + typeArguments(rest)); ok {
+		return name, strings.TrimPrefix(rest, typeArguments(rest))
+	}
+	if !strings.HasPrefix(rest, "[") {
+		return exportedName(unqualified(identifier)), rest
+	}
+
+	var args []string
+	rest = rest[1:]
+	for {
+		var arg string
+		arg, rest = readableTypeName(strings.TrimLeft(rest, " "), resolve)
+		args = append(args, arg)
+		if !strings.HasPrefix(rest, ",") {
+			break
+		}
+		rest = rest[1:]
+	}
+	return exportedName(unqualified(identifier)) + "Of" + strings.Join(args, "And"), strings.TrimPrefix(rest, "]")
+}
+
+// typeArguments returns the bracketed type arguments at the start of s.
+func typeArguments(s string) string {
+	if !strings.HasPrefix(s, "[") {
+		return ""
+	}
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return s[:i+1]
+			}
+		}
+	}
+	return s
+}
+
+// anonymousStructFields spells out the field names of an anonymous struct
+// type, e.g. "WithAAndB" for "struct { A int; B string }". Embedded fields
+// are named after their type.
+func anonymousStructFields(s string) string {
+	body := strings.TrimSuffix(strings.TrimPrefix(s, "struct {"), "}")
+	var names []string
+	for _, field := range splitFields(body) {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		name, _, hasType := strings.Cut(field, " ")
+		if !hasType || strings.HasPrefix(field, "*") || strings.ContainsAny(name, ".[") {
+			name = unqualified(strings.TrimPrefix(strings.Fields(field)[0], "*")) // embedded
+		}
+		names = append(names, exportedName(name))
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return "With" + strings.Join(names, "And")
+}
+
+// splitFields splits the fields of a struct type at the semicolons outside
+// quoted tags and nested types.
+func splitFields(s string) []string {
+	var fields []string
+	depth, start, quoted := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			depth--
+		case c == ';' && depth == 0:
+			fields = append(fields, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(fields, s[start:])
+}
+
+// refName turns a definition name into a part of a generic definition name,
+// e.g. "other.User" into "OtherUser".
+func refName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = exportedName(part)
+	}
+	return strings.Join(parts, "")
+}
+
+// unqualified drops the package path of a qualified identifier, e.g.
+// "example.com/app/dto.User" or "gopkg.in/yaml.v3.Node".
+func unqualified(identifier string) string {
+	if i := strings.LastIndex(identifier, "."); i >= 0 {
+		return identifier[i+1:]
+	}
+	return identifier
+}
+
+func exportedName(name string) string {
+	if name == "any" {
+		return "Object"
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// skipBraces returns s after the closing brace matching its first opening
+// one, ignoring braces in quoted struct tags.
+func skipBraces(s string) string {
+	depth, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return s[i+1:]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,274 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/swagger/testdata/dto"
+	"github.com/ruiborda/go-swagger-generator/src/swagger/testdata/other"
+	v1 "github.com/ruiborda/go-swagger-generator/src/swagger/testdata/v1/model"
+	v2 "github.com/ruiborda/go-swagger-generator/src/swagger/testdata/v2/model"
+)
+
+type page[T any] struct {
+	Items []T `json:"items"`
+}
+
+type pair[A, B any] struct {
+	First  A `json:"first"`
+	Second B `json:"second"`
+}
+
+type phantom[T any] struct {
+	ID string `json:"id"`
+}
+
+// definitionKeys returns the sorted definition names of the built doc.
+func definitionKeys(doc *SwaggerDocBuilder) []string {
+	var names []string
+	for name := range doc.Build().Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestGenericDefinitionNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []reflect.Type
+		want  string
+	}{
+		{"argument", []reflect.Type{reflect.TypeFor[page[dto.User]]()}, "PageOfUser"},
+		{"pointer argument", []reflect.Type{reflect.TypeFor[page[*dto.User]]()}, "PageOfNullableUser"},
+		{"basic arguments", []reflect.Type{reflect.TypeFor[pair[string, int]]()}, "PairOfStringAndInt"},
+		{"slice argument", []reflect.Type{reflect.TypeFor[page[[]dto.User]]()}, "PageOfUserList"},
+		{"array argument", []reflect.Type{reflect.TypeFor[page[[2]dto.User]]()}, "PageOfUserList"},
+		{"map argument", []reflect.Type{reflect.TypeFor[page[map[string]dto.User]]()}, "PageOfMapOfStringToUser"},
+		{"generic argument", []reflect.Type{reflect.TypeFor[page[page[dto.User]]]()}, "PageOfPageOfUser"},
+		{"any argument", []reflect.Type{reflect.TypeFor[phantom[any]]()}, "PhantomOfObject"},
+		{"anonymous struct argument", []reflect.Type{reflect.TypeFor[page[struct{ A, B int }]]()}, "PageOfObjectWithAAndB"},
+		{"tagged anonymous struct argument", []reflect.Type{reflect.TypeFor[page[struct {
+			A int `json:"a;b"`
+		}]]()}, "PageOfObjectWithA"},
+		{"qualified argument", []reflect.Type{reflect.TypeFor[dto.User](), reflect.TypeFor[page[other.User]]()}, "PageOfOtherUser"},
+		{"unused arguments", []reflect.Type{reflect.TypeFor[phantom[dto.Tag]](), reflect.TypeFor[phantom[other.Tag]]()}, "PhantomOfOtherTag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New().DefinitionCollisions(openapi2.CollisionQualify)
+			for _, typ := range tt.types {
+				if _, err := doc.SchemaFromType(typ); err != nil {
+					t.Fatal(err)
+				}
+			}
+			names := definitionKeys(doc)
+			if i := sort.SearchStrings(names, tt.want); i == len(names) || names[i] != tt.want {
+				t.Errorf("definitions %v, want %s", names, tt.want)
+			}
+			if errs := doc.Errors(); len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestGenericDefinitionNamesOfPointerAndValue(t *testing.T) {
+	doc := New()
+	doc.SchemaFromType(reflect.TypeFor[page[dto.User]]())
+	doc.SchemaFromType(reflect.TypeFor[page[*dto.User]]())
+	want := []string{"PageOfNullableUser", "PageOfUser", "Tag", "User"}
+	if got := definitionKeys(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("definitions %v, want %v", got, want)
+	}
+	if errs := doc.Errors(); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+type taggedPets struct {
+	Own    dto.Tag     `json:"own"`
+	Other  other.Tag   `json:"other"`
+	Others []other.Tag `json:"others"`
+}
+
+func TestDefinitionCollisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy openapi2.CollisionStrategy
+		types    []reflect.Type
+		want     []string
+		errors   []string
+	}{
+		{
+			name:     "error",
+			strategy: openapi2.CollisionError,
+			types:    []reflect.Type{reflect.TypeFor[dto.Tag](), reflect.TypeFor[other.Tag]()},
+			want:     []string{"dto.Tag", "other.Tag"},
+			errors:   []string{"definitions.Tag: github.com/ruiborda/go-swagger-generator/src/swagger/testdata/dto.Tag and github.com/ruiborda/go-swagger-generator/src/swagger/testdata/other.Tag have the same definition name"},
+		},
+		{
+			name:     "qualify",
+			strategy: openapi2.CollisionQualify,
+			types:    []reflect.Type{reflect.TypeFor[dto.Tag](), reflect.TypeFor[other.Tag]()},
+			want:     []string{"dto.Tag", "other.Tag"},
+		},
+		{
+			name:     "qualify with parent packages",
+			strategy: openapi2.CollisionQualify,
+			types:    []reflect.Type{reflect.TypeFor[v1.Item](), reflect.TypeFor[v2.Item]()},
+			want:     []string{"v1.model.Item", "v2.model.Item"},
+		},
+		{
+			name:     "qualify generic arguments",
+			strategy: openapi2.CollisionQualify,
+			types:    []reflect.Type{reflect.TypeFor[page[dto.Tag]](), reflect.TypeFor[page[other.Tag]]()},
+			want:     []string{"PageOfDtoTag", "PageOfOtherTag", "dto.Tag", "other.Tag"},
+		},
+		{
+			name:     "collision through a field",
+			strategy: openapi2.CollisionError,
+			types:    []reflect.Type{reflect.TypeFor[dto.User](), reflect.TypeFor[other.Tag]()},
+			want:     []string{"User", "dto.Tag", "other.Tag"},
+			errors:   []string{"definitions.Tag: github.com/ruiborda/go-swagger-generator/src/swagger/testdata/dto.Tag and github.com/ruiborda/go-swagger-generator/src/swagger/testdata/other.Tag have the same definition name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var specs []string
+			for _, reversed := range []bool{false, true} {
+				doc := New().DefinitionCollisions(tt.strategy)
+				for i := range tt.types {
+					if reversed {
+						i = len(tt.types) - 1 - i
+					}
+					doc.SchemaFromType(tt.types[i])
+				}
+				if got := definitionKeys(doc); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("reversed %v: definitions %v, want %v", reversed, got, tt.want)
+				}
+				var errs []string
+				for _, err := range doc.Errors() {
+					errs = append(errs, err.Error())
+				}
+				if !reflect.DeepEqual(errs, tt.errors) {
+					t.Errorf("reversed %v: errors %q, want %q", reversed, errs, tt.errors)
+				}
+				spec, _ := json.Marshal(doc.Build())
+				specs = append(specs, string(spec))
+			}
+			if specs[0] != specs[1] {
+				t.Errorf("the document depends on the registration order:\n%s\n%s", specs[0], specs[1])
+			}
+		})
+	}
+}
+
+func TestDefinitionCollisionReferences(t *testing.T) {
+	doc := New().DefinitionCollisions(openapi2.CollisionQualify)
+	doc.SchemaFromType(reflect.TypeFor[taggedPets]())
+	doc.Path("/tags").Get(func(op openapi2.Operation) {
+		op.Response(200, func(r openapi2.Response) {
+			ResponseOf[dto.Tag](r.Description("Tag"))
+		})
+	})
+	built := doc.Build()
+	properties := built.Definitions["taggedPets"].Properties
+	refs := map[string]string{
+		"own":    properties["own"].Ref,
+		"other":  properties["other"].Ref,
+		"others": properties["others"].Items.Ref,
+		"tags":   built.Paths["/tags"].Get.Responses["200"].Schema.Ref,
+	}
+	want := map[string]string{
+		"own":    "#/definitions/dto.Tag",
+		"other":  "#/definitions/other.Tag",
+		"others": "#/definitions/other.Tag",
+		"tags":   "#/definitions/dto.Tag",
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("references %v, want %v", refs, want)
+	}
+	if violations := doc.Validate(); len(violations) > 0 {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+}
+
+func TestDefinitionNamer(t *testing.T) {
+	packageNamer := func(t reflect.Type) string {
+		if t.Name() != "Tag" {
+			return ""
+		}
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		return strings.ToUpper(pkg[:1]) + pkg[1:] + t.Name()
+	}
+	tests := []struct {
+		name   string
+		namer  func(t reflect.Type) string
+		types  []reflect.Type
+		want   []string
+		errors int
+	}{
+		{
+			name:  "package prefix",
+			namer: packageNamer,
+			types: []reflect.Type{reflect.TypeFor[dto.Tag](), reflect.TypeFor[other.Tag](), reflect.TypeFor[other.User]()},
+			want:  []string{"DtoTag", "OtherTag", "User"},
+		},
+		{
+			name:   "same name in different packages",
+			namer:  func(reflect.Type) string { return "Thing" },
+			types:  []reflect.Type{reflect.TypeFor[dto.Tag](), reflect.TypeFor[other.Tag]()},
+			want:   []string{"dto.Thing", "other.Thing"},
+			errors: 1,
+		},
+		{
+			name:   "same name in one package",
+			namer:  func(reflect.Type) string { return "Thing" },
+			types:  []reflect.Type{reflect.TypeFor[other.User](), reflect.TypeFor[other.Tag]()},
+			want:   []string{"Thing", "Thing2"},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New().DefinitionNamer(tt.namer)
+			for _, typ := range tt.types {
+				doc.SchemaFromType(typ)
+			}
+			if got := definitionKeys(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("definitions %v, want %v", got, tt.want)
+			}
+			if errs := doc.Errors(); len(errs) != tt.errors {
+				t.Errorf("errors %v, want %d", errs, tt.errors)
+			}
+		})
+	}
+}
+
+func TestReadableTypeName(t *testing.T) {
+	unresolved := func(string) (string, bool) { return "", false }
+	tests := []struct {
+		in, want, rest string
+	}{
+		{"example.com/app/dto.User", "User", ""},
+		{"*example.com/app/dto.User]", "NullableUser", "]"},
+		{"[]*example.com/app/dto.User,int", "NullableUserList", ",int"},
+		{"map[string][]int]", "MapOfStringToIntList", "]"},
+		{"example.com/app.Page[example.com/app/dto.User]", "PageOfUser", ""},
+		{"gopkg.in/yaml.v3.Node", "Node", ""},
+		{"struct { A int \"json:\\\"a;}\\\"\"; B string }]", "ObjectWithAAndB", "]"},
+		{"struct { example.com/app/dto.User; *example.com/app/dto.Tag }", "ObjectWithUserAndTag", ""},
+		{"interface {}", "Object", ""},
+	}
+	for _, tt := range tests {
+		got, rest := readableTypeName(tt.in, unresolved)
+		if got != tt.want || rest != tt.rest {
+			t.Errorf("readableTypeName(%q) = %q, %q, want %q, %q", tt.in, got, rest, tt.want, tt.rest)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package swagger
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+
+	"github.com/ruiborda/go-swagger-generator/src/doccomments"
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// definitionSource remembers the Go type a definition was generated from and
+// the Go field behind each property, to look up their doc comments.
+type definitionSource struct {
+	typ    reflect.Type
+	fields map[string]fieldSource
+}
+
+// fieldSource is a Go field and the struct declaring it, which is an
+// embedded struct for promoted fields.
+type fieldSource struct {
+	owner reflect.Type
+	name  string
+}
+
+// DocComments sets the Go doc comments used as descriptions when the document
+// is built. Definitions generated from DTOs get the type comment as Title
+// (first sentence) and Description, and their properties the field comments;
+// operations documented with Handler get the handler's comment as Summary and
+// Description. Values set explicitly are never replaced.
+func (b *SwaggerDocBuilder) DocComments(comments doccomments.Comments) *SwaggerDocBuilder {
+	b.comments.Merge(comments)
+	return b
+}
+
+// DefinitionPackages returns the import paths of the packages that declare
+// the DTOs registered so far, i.e. the packages to extract comments from.
+// Commands are left out since "main" is not an import path; extract them
+// by directory instead.
+func (b *SwaggerDocBuilder) DefinitionPackages() []string {
+	seen := make(map[string]bool)
+	var packages []string
+	for _, source := range b.definitionSources {
+		if pkg := source.typ.PkgPath(); pkg != "" && pkg != "main" && !seen[pkg] {
+			seen[pkg] = true
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+func (b *SwaggerDocBuilder) recordDefinition(name string, t reflect.Type, fields map[string]fieldSource) {
+	if b.definitionSources == nil {
+		b.definitionSources = make(map[string]definitionSource)
+	}
+	b.definitionSources[name] = definitionSource{typ: t, fields: fields}
+}
+
+func (b *SwaggerDocBuilder) recordHandler(op *entity2.OperationEntity, handler interface{}) {
+	value := reflect.ValueOf(handler)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return
+	}
+	fn := runtime.FuncForPC(value.Pointer())
+	if fn == nil {
+		return
+	}
+	if b.handlers == nil {
+		b.handlers = make(map[*entity2.OperationEntity]string)
+	}
+	b.handlers[op] = fn.Name()
+}
+
+// describedDefinitions returns a copy of definitions with the doc comments of
+// their Go types applied.
+func (b *SwaggerDocBuilder) describedDefinitions(definitions map[string]entity2.SchemaEntity) map[string]entity2.SchemaEntity {
+	if len(b.comments.Types) == 0 || len(b.definitionSources) == 0 {
+		return definitions
+	}
+	result := make(map[string]entity2.SchemaEntity, len(definitions))
+	for name, schema := range definitions {
+		source, ok := b.definitionSources[name]
+		if !ok {
+			result[name] = schema
+			continue
+		}
+		comments, _ := b.comments.Type(source.typ.PkgPath(), genericBaseName(source.typ))
+		if schema.Title == "" && comments.Doc != "" {
+			schema.Title = doccomments.Title(comments.Doc)
+		}
+		if schema.Description == "" {
+			schema.Description = comments.Doc
+		}
+		if n := len(schema.AllOf); n > 0 && schema.AllOf[n-1] != nil {
+			// EmbeddedAllOf keeps the struct's own fields in the last schema
+			allOf := append([]*entity2.SchemaEntity(nil), schema.AllOf...)
+			own := *allOf[n-1]
+			own.Properties = b.describedProperties(source, comments, own.Properties)
+			allOf[n-1] = &own
+			schema.AllOf = allOf
+		}
+		schema.Properties = b.describedProperties(source, comments, schema.Properties)
+		result[name] = schema
+	}
+	return result
+}
+
+// describedProperties returns a copy of the properties of a definition with
+// the doc comments of their fields applied.
+func (b *SwaggerDocBuilder) describedProperties(source definitionSource, comments doccomments.Type, properties map[string]*entity2.SchemaEntity) map[string]*entity2.SchemaEntity {
+	if properties == nil {
+		return nil
+	}
+	result := make(map[string]*entity2.SchemaEntity, len(properties))
+	for propName, prop := range properties {
+		doc := b.fieldComment(source.typ, comments, source.fields[propName])
+		if prop != nil && prop.Description == "" && doc != "" {
+			described := *prop
+			if prop.Ref != "" { // $ref siblings are ignored, see docTaggedSchema
+				described = entity2.SchemaEntity{AllOf: []*entity2.SchemaEntity{prop}}
+			}
+			described.Description = doc
+			prop = &described
+		}
+		result[propName] = prop
+	}
+	return result
+}
+
+// fieldComment returns the doc comment of a field, declared by t, whose
+// comments are given, or by a struct embedded in t.
+func (b *SwaggerDocBuilder) fieldComment(t reflect.Type, comments doccomments.Type, field fieldSource) string {
+	if field.owner == nil || field.owner == t {
+		return comments.Fields[field.name]
+	}
+	owner, _ := b.comments.Type(field.owner.PkgPath(), genericBaseName(field.owner))
+	return owner.Fields[field.name]
+}
+
+// describedPaths returns a copy of paths whose operations documented with
+// Handler carry the handler's doc comment.
+func (b *SwaggerDocBuilder) describedPaths(paths map[string]entity2.PathItemEntity) map[string]entity2.PathItemEntity {
+	if len(b.comments.Funcs) == 0 || len(b.handlers) == 0 {
+		return paths
+	}
+	describe := func(op *entity2.OperationEntity) *entity2.OperationEntity {
+		name, ok := b.handlers[op]
+		if !ok {
+			return op
+		}
+		doc, ok := b.comments.Func(name)
+		if !ok {
+			return op
+		}
+		described := *op
+		if described.Summary == "" {
+			described.Summary = doccomments.Title(doc)
+		}
+		if described.Description == "" {
+			described.Description = doc
+		}
+		return &described
+	}
+	result := make(map[string]entity2.PathItemEntity, len(paths))
+	for path, item := range paths {
+		item.Get = describe(item.Get)
+		item.Post = describe(item.Post)
+		item.Put = describe(item.Put)
+		item.Delete = describe(item.Delete)
+		item.Options = describe(item.Options)
+		item.Head = describe(item.Head)
+		item.Patch = describe(item.Patch)
+		result[path] = item
+	}
+	return result
+}
@@ -0,0 +1,177 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// swaggerIgnored reports whether the field is excluded with swaggerignore:"true".
+func swaggerIgnored(field reflect.StructField) bool {
+	ignore, _ := strconv.ParseBool(field.Tag.Get("swaggerignore"))
+	return ignore
+}
+
+// applyDocTags copies the documentation tags of a struct field onto its
+// schema. Tag names follow swaggo so existing DTOs keep working:
+//
+//	description, example, default, format, enums, minimum, maximum,
+//	minLength, maxLength, multipleOf, pattern, readonly (or readOnly)
+//
+// Values are parsed into the field's type, so an int field's example is a
+// JSON number. For slices, example and default hold comma separated items
+// and enums applies to the items. Tags whose value cannot be parsed are left
+// out and returned as errors.
+func applyDocTags(schema *entity2.SchemaEntity, field reflect.StructField) []error {
+	if schema.Ref != "" {
+		return nil // see docTaggedSchema
+	}
+	tag := field.Tag
+	t := field.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var errs []error
+	check := func(name string, err error) bool {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s tag %q: %w", name, tag.Get(name), err))
+		}
+		return err == nil
+	}
+
+	if description, ok := tag.Lookup("description"); ok {
+		schema.Description = description
+	}
+	if format, ok := tag.Lookup("format"); ok {
+		schema.Format = format
+	}
+	if pattern, ok := tag.Lookup("pattern"); ok {
+		schema.Pattern = pattern
+	}
+	if example, ok := tag.Lookup("example"); ok {
+		if value, err := parseDocTagValue(t, example); check("example", err) {
+			schema.Example = value
+		}
+	}
+	if def, ok := tag.Lookup("default"); ok {
+		if value, err := parseDocTagValue(t, def); check("default", err) {
+			schema.Default = value
+		}
+	}
+	if enums, ok := tag.Lookup("enums"); ok {
+		target, elem := schema, t
+		if isListKind(t) && schema.Items != nil {
+			target, elem = schema.Items, t.Elem()
+		}
+		var enum []interface{}
+		var err error
+		for _, item := range strings.Split(enums, ",") {
+			var value interface{}
+			if value, err = parseTagValue(elem, strings.TrimSpace(item)); err != nil {
+				break
+			}
+			enum = append(enum, value)
+		}
+		if check("enums", err) {
+			restrictEnum(target, enum)
+		}
+	}
+	if readOnly, ok := lookupReadOnly(tag); ok {
+		if value, err := strconv.ParseBool(readOnly); err == nil {
+			schema.ReadOnly = value
+		} else {
+			errs = append(errs, fmt.Errorf("readonly tag %q: is not a boolean", readOnly))
+		}
+	}
+
+	if value, ok, err := floatTag(tag, "minimum"); check("minimum", err) && ok {
+		schema.Minimum = &value
+	}
+	if value, ok, err := floatTag(tag, "maximum"); check("maximum", err) && ok {
+		schema.Maximum = &value
+	}
+	if value, ok, err := floatTag(tag, "multipleOf"); check("multipleOf", err) && ok {
+		schema.MultipleOf = &value
+	}
+	if value, ok, err := intTag(tag, "minLength"); check("minLength", err) && ok {
+		schema.MinLength = &value
+	}
+	if value, ok, err := intTag(tag, "maxLength"); check("maxLength", err) && ok {
+		schema.MaxLength = &value
+	}
+	return errs
+}
+
+// docTaggedSchema returns schema with the documentation tags of field applied.
+// Swagger 2.0 ignores the siblings of a $ref, so a field referencing a
+// definition is described next to an allOf of the reference instead.
+func docTaggedSchema(schema *entity2.SchemaEntity, field reflect.StructField) (*entity2.SchemaEntity, []error) {
+	if schema.Ref == "" {
+		return schema, applyDocTags(schema, field)
+	}
+	wrapper := &entity2.SchemaEntity{}
+	errs := applyDocTags(wrapper, field)
+	if reflect.ValueOf(*wrapper).IsZero() {
+		return schema, errs
+	}
+	wrapper.AllOf = []*entity2.SchemaEntity{schema}
+	return wrapper, errs
+}
+
+// parseDocTagValue parses an example or default value; slices take a comma
+// separated list of items.
+func parseDocTagValue(t reflect.Type, s string) (interface{}, error) {
+	if !isListKind(t) || t.Elem().Kind() == reflect.Uint8 {
+		return parseTagValue(t, s)
+	}
+	items := make([]interface{}, 0)
+	if s == "" {
+		return items, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		value, err := parseTagValue(t.Elem(), strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	return items, nil
+}
+
+func isListKind(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+}
+
+func lookupReadOnly(tag reflect.StructTag) (string, bool) {
+	if value, ok := tag.Lookup("readonly"); ok {
+		return value, true
+	}
+	return tag.Lookup("readOnly")
+}
+
+func floatTag(tag reflect.StructTag, name string) (float64, bool, error) {
+	raw, ok := tag.Lookup(name)
+	if !ok {
+		return 0, false, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("is not a number")
+	}
+	return value, true, nil
+}
+
+func intTag(tag reflect.StructTag, name string) (int, bool, error) {
+	raw, ok := tag.Lookup(name)
+	if !ok {
+		return 0, false, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, false, fmt.Errorf("is not a valid length")
+	}
+	return value, true, nil
+}
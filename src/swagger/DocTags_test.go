@@ -0,0 +1,121 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// docTagged returns the JSON of the schema of a field of type typ carrying
+// tag, and the errors raised by its documentation tags.
+func docTagged(t *testing.T, typ reflect.Type, tag string) (string, []string) {
+	t.Helper()
+	schema, err := New().GenerateSchemaFromGoType(typ, make(map[string]bool))
+	if err != nil {
+		t.Fatal(err)
+	}
+	field := reflect.StructField{Name: "F", Type: typ, Tag: reflect.StructTag(tag)}
+	schema, tagErrs := docTaggedSchema(schema, field)
+	var errs []string
+	for _, err := range tagErrs {
+		errs = append(errs, err.Error())
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), errs
+}
+
+type docTaggedOwner struct {
+	Name string `json:"name"`
+}
+
+func TestDocTags(t *testing.T) {
+	str := reflect.TypeFor[string]()
+	integer := reflect.TypeFor[int]()
+	tests := []struct {
+		typ  reflect.Type
+		tag  string
+		want string
+	}{
+		{str, `description:"The name" format:"email" pattern:"^a"`, `{"type":"string","description":"The name","format":"email","pattern":"^a"}`},
+		{str, `example:"doggie" default:"rex"`, `{"type":"string","example":"doggie","default":"rex"}`},
+		{integer, `example:"10" default:"-1"`, `{"type":"integer","format":"int32","example":10,"default":-1}`},
+		{reflect.TypeFor[uint8](), `example:"255"`, `{"type":"integer","format":"int32","example":255}`},
+		{reflect.TypeFor[float64](), `example:"12.5" minimum:"0" maximum:"100" multipleOf:"0.5"`, `{"type":"number","format":"double","example":12.5,"minimum":0,"maximum":100,"multipleOf":0.5}`},
+		{reflect.TypeFor[bool](), `example:"true" default:"false"`, `{"type":"boolean","example":true,"default":false}`},
+		{reflect.TypeFor[*int](), `example:"3"`, `{"type":"integer","format":"int32","example":3}`},
+		{str, `enums:"available, pending,sold"`, `{"type":"string","enum":["available","pending","sold"]}`},
+		{integer, `enums:"1,2,3"`, `{"type":"integer","format":"int32","enum":[1,2,3]}`},
+		{reflect.TypeFor[[]int](), `example:"1,2" default:"" enums:"1,2,3"`, `{"type":"array","items":{"type":"integer","format":"int32","enum":[1,2,3]},"example":[1,2],"default":[]}`},
+		{str, `minLength:"1" maxLength:"64"`, `{"type":"string","minLength":1,"maxLength":64}`},
+		{str, `readonly:"true"`, `{"type":"string","readOnly":true}`},
+		{str, `readOnly:"true"`, `{"type":"string","readOnly":true}`},
+		{reflect.TypeFor[docTaggedOwner](), `description:"The owner" example:"x"`, `{"description":"The owner","example":"x","allOf":[{"$ref":"#/definitions/docTaggedOwner"}]}`},
+		{reflect.TypeFor[docTaggedOwner](), `json:"owner"`, `{"$ref":"#/definitions/docTaggedOwner"}`},
+	}
+	for _, tt := range tests {
+		got, errs := docTagged(t, tt.typ, tt.tag)
+		if !sameJSON(got, tt.want) {
+			t.Errorf("%s `%s`: %s, want %s", tt.typ, tt.tag, got, tt.want)
+		}
+		if len(errs) > 0 {
+			t.Errorf("%s `%s`: unexpected errors %q", tt.typ, tt.tag, errs)
+		}
+	}
+}
+
+func TestMalformedDocTags(t *testing.T) {
+	str := reflect.TypeFor[string]()
+	integer := reflect.TypeFor[int]()
+	tests := []struct {
+		typ  reflect.Type
+		tag  string
+		want string
+		errs []string
+	}{
+		{integer, `example:"ten"`, `{"type":"integer","format":"int32"}`, []string{`example tag "ten": "ten" is not a valid int value`}},
+		{reflect.TypeFor[int8](), `default:"300"`, `{"type":"integer","format":"int32"}`, []string{`default tag "300": "300" is not a valid int8 value`}},
+		{reflect.TypeFor[uint](), `example:"-1"`, `{"type":"integer","format":"int32"}`, []string{`example tag "-1": "-1" is not a valid uint value`}},
+		{reflect.TypeFor[float64](), `default:"1,5"`, `{"type":"number","format":"double"}`, []string{`default tag "1,5": "1,5" is not a valid float64 value`}},
+		{reflect.TypeFor[bool](), `example:"yes"`, `{"type":"boolean"}`, []string{`example tag "yes": "yes" is not a valid bool value`}},
+		{reflect.TypeFor[[]int](), `example:"1,x"`, `{"type":"array","items":{"type":"integer","format":"int32"}}`, []string{`example tag "1,x": "x" is not a valid int value`}},
+		{integer, `enums:"1,two"`, `{"type":"integer","format":"int32"}`, []string{`enums tag "1,two": "two" is not a valid int value`}},
+		{str, `minimum:"low" maxLength:"-1" minLength:"x"`, `{"type":"string"}`, []string{`minimum tag "low": is not a number`, `minLength tag "x": is not a valid length`, `maxLength tag "-1": is not a valid length`}},
+		{str, `readonly:"maybe"`, `{"type":"string"}`, []string{`readonly tag "maybe": is not a boolean`}},
+		{reflect.TypeFor[docTaggedOwner](), `maximum:"x"`, `{"$ref":"#/definitions/docTaggedOwner"}`, []string{`maximum tag "x": is not a number`}},
+	}
+	for _, tt := range tests {
+		got, errs := docTagged(t, tt.typ, tt.tag)
+		if !sameJSON(got, tt.want) {
+			t.Errorf("%s `%s`: %s, want %s", tt.typ, tt.tag, got, tt.want)
+		}
+		if !reflect.DeepEqual(errs, tt.errs) {
+			t.Errorf("%s `%s`: errors %q, want %q", tt.typ, tt.tag, errs, tt.errs)
+		}
+	}
+}
+
+type malformedDocTags struct {
+	Age int `json:"age" example:"old" minimum:"0"`
+}
+
+func TestMalformedDocTagsReported(t *testing.T) {
+	doc := New()
+	if _, err := doc.SchemaFromType(reflect.TypeFor[malformedDocTags]()); err != nil {
+		t.Fatal(err)
+	}
+	var errs []string
+	for _, err := range doc.Errors() {
+		errs = append(errs, err.Error())
+	}
+	want := []string{`definitions.malformedDocTags field Age: example tag "old": "old" is not a valid int value`}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors %q, want %q", errs, want)
+	}
+	age := doc.Build().Definitions["malformedDocTags"].Properties["age"]
+	if age.Example != nil || age.Minimum == nil {
+		t.Errorf("age %+v, want the minimum and no example", age)
+	}
+}
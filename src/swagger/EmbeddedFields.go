@@ -0,0 +1,245 @@
+package swagger
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// jsonField is a field of a struct as encoding/json sees it, possibly
+// promoted from an embedded struct.
+type jsonField struct {
+	name      string
+	tagged    bool // the name comes from the json tag
+	omitempty bool
+	field     reflect.StructField
+	owner     reflect.Type // the struct declaring the field
+	index     []int
+	// viaPointer is set for fields promoted through an embedded pointer,
+	// which encoding/json leaves out when the pointer is nil
+	viaPointer bool
+}
+
+// EmbeddedAllOf describes structs with embedded structs as an allOf of the
+// definitions of the embedded structs and an object with the struct's own
+// fields, instead of flattening the promoted fields into the struct the way
+// encoding/json does. Fields shadowing a promoted field then appear twice.
+func (b *SwaggerDocBuilder) EmbeddedAllOf(allOf bool) *SwaggerDocBuilder {
+	b.definitionsMux.Lock()
+	defer b.definitionsMux.Unlock()
+	b.embeddedAllOf = allOf
+	return b
+}
+
+// jsonFields returns the fields encoding/json encodes for the struct t, in
+// the same order. Fields of embedded structs without a json name are
+// promoted; among fields with the same name the shallowest one wins, a
+// tagged one breaks ties and remaining ties drop the name altogether.
+func jsonFields(t reflect.Type) []jsonField {
+	type embedded struct {
+		typ        reflect.Type
+		index      []int
+		viaPointer bool
+	}
+	var fields []jsonField
+	var current []embedded
+	next := []embedded{{typ: t}}
+	count, nextCount := map[reflect.Type]int{}, map[reflect.Type]int{}
+	visited := map[reflect.Type]bool{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue // unexported non-struct embedded fields are ignored
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" || swaggerIgnored(sf) {
+					continue
+				}
+				name, options, _ := strings.Cut(tag, ",")
+				index := append(append([]int(nil), e.index...), i)
+
+				ft := sf.Type
+				pointer := false
+				if ft.Name() == "" && ft.Kind() == reflect.Ptr {
+					ft, pointer = ft.Elem(), true
+				}
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					field := jsonField{
+						name:       name,
+						tagged:     name != "",
+						omitempty:  hasTagOption(options, "omitempty"),
+						field:      sf,
+						owner:      e.typ,
+						index:      index,
+						viaPointer: e.viaPointer,
+					}
+					if field.name == "" {
+						field.name = sf.Name
+					}
+					fields = append(fields, field)
+					if count[e.typ] > 1 {
+						// the struct is embedded twice at this depth, its
+						// fields annihilate each other
+						fields = append(fields, field)
+					}
+					continue
+				}
+
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, embedded{typ: ft, index: index, viaPointer: e.viaPointer || pointer})
+				}
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		x, y := fields[i], fields[j]
+		if x.name != y.name {
+			return x.name < y.name
+		}
+		if len(x.index) != len(y.index) {
+			return len(x.index) < len(y.index)
+		}
+		if x.tagged != y.tagged {
+			return x.tagged
+		}
+		return indexLess(x.index, y.index)
+	})
+
+	dominant := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != fields[i].name {
+				break
+			}
+		}
+		same := fields[i : i+advance]
+		if len(same) > 1 && len(same[0].index) == len(same[1].index) && same[0].tagged == same[1].tagged {
+			continue // ambiguous, encoding/json drops the name
+		}
+		dominant = append(dominant, same[0])
+	}
+
+	sort.Slice(dominant, func(i, j int) bool {
+		return indexLess(dominant[i].index, dominant[j].index)
+	})
+	return dominant
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// promotedMarshaler returns the embedded field whose MarshalJSON or
+// MarshalText method the struct t promotes, and the interface it implements.
+// encoding/json encodes t with that method instead of its fields, e.g.
+// struct{ time.Time } as a timestamp.
+func promotedMarshaler(t reflect.Type) (reflect.StructField, reflect.Type, bool) {
+	for _, marshaler := range []reflect.Type{jsonMarshalerType, textMarshalerType} {
+		if !implements(t, marshaler) {
+			continue
+		}
+		if declaresMethod(t, marshaler.Method(0).Name) {
+			break
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if sf := t.Field(i); sf.Anonymous && implements(sf.Type, marshaler) {
+				return sf, marshaler, true
+			}
+		}
+		break
+	}
+	return reflect.StructField{}, nil, false
+}
+
+// declaresMethod reports whether t or *t declares the method itself rather
+// than promoting it from an embedded field, through a wrapper the compiler
+// generates.
+func declaresMethod(t reflect.Type, name string) bool {
+	for _, candidate := range []reflect.Type{t, reflect.PointerTo(t)} {
+		method, ok := candidate.MethodByName(name)
+		if !ok {
+			continue
+		}
+		fn := runtime.FuncForPC(method.Func.Pointer())
+		if fn == nil {
+			return true
+		}
+		file, _ := fn.FileLine(fn.Entry())
+		return file != "<autogenerated>"
+	}
+	return false
+}
+
+// implements reports whether t or, for addressable values, *t implements iface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || (t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(iface))
+}
+
+// embeddedStructs returns the embedded fields of t whose fields are promoted
+// by encoding/json.
+func embeddedStructs(t reflect.Type) []reflect.StructField {
+	var result []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.Anonymous || swaggerIgnored(sf) {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if name == "" && ft.Kind() == reflect.Struct {
+			result = append(result, sf)
+		}
+	}
+	return result
+}
+
+func hasTagOption(options, option string) bool {
+	for options != "" {
+		var current string
+		current, options, _ = strings.Cut(options, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
+
+func indexLess(x, y []int) bool {
+	for i, xi := range x {
+		if i >= len(y) {
+			return false
+		}
+		if xi != y[i] {
+			return xi < y[i]
+		}
+	}
+	return len(x) < len(y)
+}
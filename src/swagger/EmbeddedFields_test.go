@@ -0,0 +1,220 @@
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+type embeddedBase struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type embeddedPet struct {
+	embeddedBase
+	Tag string `json:"tag"`
+}
+
+type embeddedShadow struct {
+	embeddedBase
+	Name string `json:"name"`
+}
+
+type embeddedLeft struct {
+	Color string
+	Size  int `json:"size"`
+}
+
+type embeddedRight struct {
+	Color string
+	Size  int
+}
+
+type embeddedAmbiguous struct {
+	embeddedLeft
+	embeddedRight
+	Kind string `json:"kind"`
+}
+
+type embeddedTwiceLeft struct{ embeddedBase }
+
+type embeddedTwiceRight struct{ embeddedBase }
+
+type embeddedTwice struct {
+	embeddedTwiceLeft
+	embeddedTwiceRight
+	Tag string `json:"tag"`
+}
+
+type embeddedNamed struct {
+	embeddedBase `json:"base"`
+	Tag          string `json:"tag"`
+}
+
+type embeddedPointer struct {
+	*embeddedBase
+	Ignored string `json:"-"`
+	secret  string
+}
+
+type embeddedExported struct {
+	Base embeddedBase
+	Tag  string `json:"tag,omitempty"`
+}
+
+// objectKeys returns the keys of the JSON object data in encoding order.
+func objectKeys(t *testing.T, data []byte) []string {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key.(string))
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return keys
+}
+
+func TestJSONFieldsMatchMarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"flattened", embeddedPet{}},
+		{"shadowed by a shallower field", embeddedShadow{}},
+		{"ambiguous fields dropped, tag breaks ties", embeddedAmbiguous{}},
+		{"struct embedded twice", embeddedTwice{}},
+		{"embedded struct with a json name", embeddedNamed{}},
+		{"embedded pointer", embeddedPointer{embeddedBase: &embeddedBase{}, secret: "s"}},
+		{"named struct field", embeddedExported{Tag: "t"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := objectKeys(t, data)
+			typ := reflect.TypeOf(tt.value)
+
+			var got []string
+			for _, f := range jsonFields(typ) {
+				got = append(got, f.name)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("jsonFields %q, json.Marshal keys %q", got, want)
+			}
+
+			doc := New()
+			if _, err := doc.SchemaFromType(typ); err != nil {
+				t.Fatal(err)
+			}
+			var properties []string
+			for name := range doc.Build().Definitions[typ.Name()].Properties {
+				properties = append(properties, name)
+			}
+			sort.Strings(properties)
+			sort.Strings(want)
+			if !reflect.DeepEqual(properties, want) {
+				t.Errorf("properties %q, json.Marshal keys %q", properties, want)
+			}
+		})
+	}
+}
+
+func TestEmbeddedRequired(t *testing.T) {
+	doc := New()
+	for _, typ := range []reflect.Type{reflect.TypeFor[embeddedPointer](), reflect.TypeFor[embeddedExported]()} {
+		if _, err := doc.SchemaFromType(typ); err != nil {
+			t.Fatal(err)
+		}
+	}
+	definitions := doc.Build().Definitions
+	// fields promoted through a nil pointer are left out by encoding/json
+	if required := definitions["embeddedPointer"].Required; len(required) != 0 {
+		t.Errorf("embeddedPointer required %q, want none", required)
+	}
+	if required := definitions["embeddedExported"].Required; !reflect.DeepEqual(required, []string{"Base"}) {
+		t.Errorf("embeddedExported required %q, want [Base]", required)
+	}
+}
+
+type embeddedTime struct{ time.Time }
+
+type embeddedTextID struct{ v string }
+
+func (id embeddedTextID) MarshalText() ([]byte, error) { return []byte(id.v), nil }
+
+type embeddedText struct{ embeddedTextID }
+
+type embeddedJSONBase struct {
+	ID int `json:"id"`
+}
+
+func (b embeddedJSONBase) MarshalJSON() ([]byte, error) {
+	type plain embeddedJSONBase
+	return json.Marshal(plain(b))
+}
+
+type embeddedJSON struct {
+	embeddedJSONBase
+	Ignored string `json:"ignored"`
+}
+
+type embeddedOverride struct {
+	embeddedTextID
+	Name string `json:"name"`
+}
+
+func (o embeddedOverride) MarshalText() ([]byte, error) { return []byte(o.Name), nil }
+
+func TestPromotedMarshalers(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"time", embeddedTime{}, `{"type":"string","format":"date-time"}`},
+		{"text marshaler", embeddedText{}, `{"type":"string"}`},
+		{"json marshaler", embeddedJSON{}, `{"$ref":"#/definitions/embeddedJSONBase"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := New().GenerateSchemaFromGoType(reflect.TypeOf(tt.value), make(map[string]bool))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _ := json.Marshal(schema)
+			if !sameJSON(string(got), tt.want) {
+				t.Errorf("schema %s, want %s", got, tt.want)
+			}
+			// the schema type agrees with what encoding/json writes
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			isString := data[0] == '"'
+			if isString != (schema.Type == "string") {
+				t.Errorf("json.Marshal wrote %s for a %q schema", data, schema.Type)
+			}
+		})
+	}
+	for _, typ := range []reflect.Type{reflect.TypeFor[embeddedPet](), reflect.TypeFor[embeddedOverride]()} {
+		if _, _, ok := promotedMarshaler(typ); ok {
+			t.Errorf("%s promotes no marshaler", typ)
+		}
+	}
+}
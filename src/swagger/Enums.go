@@ -0,0 +1,161 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// EnumValuer is implemented by Go enum types to declare their values, so
+// every schema and parameter of the type gets them as enum:
+//
+//	type PetStatus string
+//
+//	const (
+//		Available PetStatus = "available"
+//		Sold      PetStatus = "sold"
+//	)
+//
+//	func (PetStatus) EnumValues() []any {
+//		return []any{
+//			openapi.EnumValue{Value: Available, Name: "Available", Description: "Can be adopted"},
+//			openapi.EnumValue{Value: Sold, Name: "Sold"},
+//		}
+//	}
+//
+// Values may be plain values or openapi.EnumValue, whose names and
+// descriptions are written as x-enum-varnames and x-enum-descriptions.
+type EnumValuer interface {
+	EnumValues() []any
+}
+
+var enumValuerType = reflect.TypeFor[EnumValuer]()
+
+// Enum declares the values of a Go enum type, for types that cannot
+// implement EnumValuer. It takes precedence over EnumValues.
+func (b *SwaggerDocBuilder) Enum(t reflect.Type, values ...openapi2.EnumValue) *SwaggerDocBuilder {
+	b.definitionsMux.Lock()
+	defer b.definitionsMux.Unlock()
+	if b.enums == nil {
+		b.enums = make(map[reflect.Type][]openapi2.EnumValue)
+	}
+	b.enums[t] = values
+	return b
+}
+
+// EnumOf declares the values of the Go enum type T, see SwaggerDocBuilder.Enum:
+//
+//	swagger.EnumOf[PetStatus](doc,
+//		openapi.EnumValue{Value: Available, Name: "Available", Description: "Can be adopted"},
+//		openapi.EnumValue{Value: Sold, Name: "Sold"},
+//	)
+func EnumOf[T any](doc *SwaggerDocBuilder, values ...openapi2.EnumValue) *SwaggerDocBuilder {
+	return doc.Enum(reflect.TypeFor[T](), values...)
+}
+
+// ParamOf sets the type, format, items and enum of a non-body parameter from
+// the Go type T:
+//
+//	op.QueryParameter("status", func(p openapi.Parameter) {
+//		swagger.ParamOf[[]PetStatus](p.CollectionFormat("multi"))
+//	})
+func ParamOf[T any](p openapi2.Parameter) openapi2.Parameter {
+	return p.TypeOf(reflect.TypeFor[T]())
+}
+
+// enumValues returns the declared values of t, registered with Enum or
+// returned by its EnumValues method.
+func (b *SwaggerDocBuilder) enumValues(t reflect.Type) ([]openapi2.EnumValue, bool) {
+	if values, ok := b.enums[t]; ok {
+		return values, true
+	}
+	var valuer EnumValuer
+	switch {
+	case t.Implements(enumValuerType):
+		valuer, _ = reflect.Zero(t).Interface().(EnumValuer)
+	case reflect.PointerTo(t).Implements(enumValuerType):
+		valuer, _ = reflect.New(t).Interface().(EnumValuer)
+	}
+	if valuer == nil {
+		return nil, false
+	}
+	var values []openapi2.EnumValue
+	for _, value := range valuer.EnumValues() {
+		if described, ok := value.(openapi2.EnumValue); ok {
+			values = append(values, described)
+		} else {
+			values = append(values, openapi2.EnumValue{Value: value})
+		}
+	}
+	return values, true
+}
+
+// plainValue converts a value of a named type, e.g. PetStatus("available"),
+// to its underlying basic type, which is what decoded JSON is compared to.
+func plainValue(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	return value
+}
+
+// enumFields returns the enum, x-enum-varnames and x-enum-descriptions of
+// values. Names are only kept when every value has one, descriptions when
+// at least one value has one.
+func enumFields(values []openapi2.EnumValue) (enum []interface{}, names, descriptions []string) {
+	allNamed, described := true, false
+	for _, value := range values {
+		enum = append(enum, plainValue(value.Value))
+		names = append(names, value.Name)
+		descriptions = append(descriptions, value.Description)
+		allNamed = allNamed && value.Name != ""
+		described = described || value.Description != ""
+	}
+	if !allNamed {
+		names = nil
+	}
+	if !described {
+		descriptions = nil
+	}
+	return enum, names, descriptions
+}
+
+func applyEnum(schema *entity2.SchemaEntity, values []openapi2.EnumValue) {
+	schema.Enum, schema.EnumVarNames, schema.EnumDescriptions = enumFields(values)
+}
+
+// restrictEnum replaces the enum of schema, e.g. with the values of a oneof
+// binding rule, keeping the names and descriptions of the values that were
+// already described.
+func restrictEnum(schema *entity2.SchemaEntity, enum []interface{}) {
+	var values []openapi2.EnumValue
+	for _, value := range enum {
+		described := openapi2.EnumValue{Value: value}
+		for i, previous := range schema.Enum {
+			if fmt.Sprint(previous) != fmt.Sprint(value) {
+				continue
+			}
+			if i < len(schema.EnumVarNames) {
+				described.Name = schema.EnumVarNames[i]
+			}
+			if i < len(schema.EnumDescriptions) {
+				described.Description = schema.EnumDescriptions[i]
+			}
+			break
+		}
+		values = append(values, described)
+	}
+	applyEnum(schema, values)
+}
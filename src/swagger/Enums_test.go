@@ -0,0 +1,163 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+)
+
+type enumStatus string
+
+func (enumStatus) EnumValues() []any {
+	return []any{
+		openapi2.EnumValue{Value: enumStatus("available"), Name: "Available", Description: "Can be adopted"},
+		openapi2.EnumValue{Value: enumStatus("sold"), Name: "Sold"},
+	}
+}
+
+type enumLevel int
+
+func (*enumLevel) EnumValues() []any { return []any{enumLevel(1), enumLevel(2)} }
+
+// enumSize has a name for only some of its values.
+type enumSize string
+
+func (enumSize) EnumValues() []any {
+	return []any{openapi2.EnumValue{Value: enumSize("s"), Name: "Small"}, enumSize("m")}
+}
+
+type enumColor string
+
+func (enumColor) EnumValues() []any { return []any{enumColor("red")} }
+
+// enumPoint is an object enum, defined once with its values.
+type enumPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func (enumPoint) EnumValues() []any { return []any{enumPoint{0, 0}, enumPoint{1, 1}} }
+
+// enumCode is encoded by its promoted MarshalText as a string.
+type enumCode struct{ embeddedTextID }
+
+func (enumCode) EnumValues() []any { return []any{"a", "b"} }
+
+// enumWrapped is encoded by its promoted MarshalJSON as an embeddedJSONBase.
+type enumWrapped struct{ embeddedJSONBase }
+
+func (enumWrapped) EnumValues() []any { return []any{map[string]int{"id": 1}} }
+
+type enumPet struct {
+	Status  enumStatus   `json:"status"`
+	Level   *enumLevel   `json:"level,omitempty"`
+	History []enumStatus `json:"history"`
+	Origin  enumPoint    `json:"origin"`
+}
+
+func TestEnumSchemas(t *testing.T) {
+	doc := New()
+	EnumOf[enumColor](doc, openapi2.EnumValue{Value: enumColor("green"), Name: "Green"})
+	tests := []struct {
+		typ  reflect.Type
+		want string
+	}{
+		{reflect.TypeFor[enumStatus](), `{"type":"string","enum":["available","sold"],"x-enum-varnames":["Available","Sold"],"x-enum-descriptions":["Can be adopted",""]}`},
+		{reflect.TypeFor[*enumStatus](), `{"type":"string","enum":["available","sold"],"x-enum-varnames":["Available","Sold"],"x-enum-descriptions":["Can be adopted",""]}`},
+		{reflect.TypeFor[enumLevel](), `{"type":"integer","format":"int32","enum":[1,2]}`},
+		{reflect.TypeFor[enumSize](), `{"type":"string","enum":["s","m"]}`},
+		{reflect.TypeFor[enumColor](), `{"type":"string","enum":["green"],"x-enum-varnames":["Green"]}`},
+		{reflect.TypeFor[[]enumStatus](), `{"type":"array","items":{"type":"string","enum":["available","sold"],"x-enum-varnames":["Available","Sold"],"x-enum-descriptions":["Can be adopted",""]}}`},
+		{reflect.TypeFor[enumPoint](), `{"$ref":"#/definitions/enumPoint"}`},
+		{reflect.TypeFor[enumCode](), `{"type":"string","enum":["a","b"]}`},
+		{reflect.TypeFor[enumWrapped](), `{"allOf":[{"$ref":"#/definitions/embeddedJSONBase"}],"enum":[{"id":1}]}`},
+	}
+	for _, tt := range tests {
+		schema, err := doc.GenerateSchemaFromGoType(tt.typ, make(map[string]bool))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := json.Marshal(schema)
+		if !sameJSON(string(got), tt.want) {
+			t.Errorf("%s: %s, want %s", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestEnumDefinitions(t *testing.T) {
+	doc := New()
+	if _, err := doc.SchemaFromType(reflect.TypeFor[enumPet]()); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"enumPet": `{"type":"object","required":["status","history","origin"],"properties":{
+			"status":{"type":"string","enum":["available","sold"],"x-enum-varnames":["Available","Sold"],"x-enum-descriptions":["Can be adopted",""]},
+			"level":{"type":"integer","format":"int32","enum":[1,2]},
+			"history":{"type":"array","items":{"type":"string","enum":["available","sold"],"x-enum-varnames":["Available","Sold"],"x-enum-descriptions":["Can be adopted",""]}},
+			"origin":{"$ref":"#/definitions/enumPoint"}}}`,
+		"enumPoint": `{"type":"object","required":["x","y"],"properties":{"x":{"type":"integer","format":"int32"},"y":{"type":"integer","format":"int32"}},"enum":[{"x":0,"y":0},{"x":1,"y":1}]}`,
+	}
+	for name, schema := range want {
+		if got := definitionJSON(t, doc, name); !sameJSON(got, schema) {
+			t.Errorf("%s: %s, want %s", name, got, schema)
+		}
+	}
+}
+
+type enumFilter struct {
+	Status enumStatus `json:"status" binding:"oneof=sold"`
+}
+
+func TestEnumRestrictedByBinding(t *testing.T) {
+	doc := New()
+	if _, err := doc.SchemaFromType(reflect.TypeFor[enumFilter]()); err != nil {
+		t.Fatal(err)
+	}
+	status := doc.Build().Definitions["enumFilter"].Properties["status"]
+	got, _ := json.Marshal(status)
+	want := `{"type":"string","enum":["sold"],"x-enum-varnames":["Sold"]}`
+	if !sameJSON(string(got), want) {
+		t.Errorf("status %s, want %s", got, want)
+	}
+}
+
+func TestParamOfEnum(t *testing.T) {
+	doc := New()
+	doc.Path("/pets").Get(func(op openapi2.Operation) {
+		op.QueryParameter("status", func(p openapi2.Parameter) {
+			ParamOf[enumStatus](p)
+		}).QueryParameter("statuses", func(p openapi2.Parameter) {
+			ParamOf[[]enumStatus](p.CollectionFormat("multi"))
+		}).QueryParameter("code", func(p openapi2.Parameter) {
+			ParamOf[enumCode](p)
+		}).QueryParameter("origin", func(p openapi2.Parameter) {
+			ParamOf[enumPoint](p)
+		}).QueryParameter("manual", func(p openapi2.Parameter) {
+			ParamOf[enumStatus](p).Enum("sold")
+		})
+	})
+	params := doc.Build().Paths["/pets"].Get.Parameters
+	want := []string{
+		`{"name":"status","in":"query","type":"string","enum":["available","sold"],"x-enum-varnames":["Available","Sold"],"x-enum-descriptions":["Can be adopted",""]}`,
+		`{"name":"statuses","in":"query","type":"array","collectionFormat":"multi","items":{"type":"string","enum":["available","sold"],"x-enum-varnames":["Available","Sold"],"x-enum-descriptions":["Can be adopted",""]}}`,
+		`{"name":"code","in":"query","type":"string","enum":["a","b"]}`,
+		`{"name":"origin","in":"query"}`,
+		`{"name":"manual","in":"query","type":"string","enum":["sold"]}`,
+	}
+	if len(params) != len(want) {
+		t.Fatalf("%d parameters, want %d", len(params), len(want))
+	}
+	for i, param := range params {
+		got, _ := json.Marshal(param)
+		if !sameJSON(string(got), want[i]) {
+			t.Errorf("parameter %d: %s, want %s", i, got, want[i])
+		}
+	}
+	errs := builderErrors(doc)
+	wantErrs := []string{"GET /pets parameter origin: query parameters cannot be objects, got swagger.enumPoint"}
+	if !reflect.DeepEqual(errs, wantErrs) {
+		t.Errorf("errors %q, want %q", errs, wantErrs)
+	}
+}
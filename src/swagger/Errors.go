@@ -0,0 +1,104 @@
+package swagger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// StrictEnv is the environment variable that enables strict mode in every
+// document, e.g. SWAGGER_STRICT=1 in CI.
+const StrictEnv = "SWAGGER_STRICT"
+
+// BuilderError is an error raised while documenting, located by the part of
+// the document being built, e.g. "GET /pet/{petId} response 200".
+type BuilderError struct {
+	Location string
+	Err      error
+}
+
+func (e *BuilderError) Error() string {
+	return e.Location + ": " + e.Err.Error()
+}
+
+func (e *BuilderError) Unwrap() error {
+	return e.Err
+}
+
+func strictFromEnv() bool {
+	strict, _ := strconv.ParseBool(os.Getenv(StrictEnv))
+	return strict
+}
+
+// Strict makes builder errors panic as soon as they happen instead of being
+// collected, so a broken DTO fails during package initialization. It is
+// enabled by default when the SWAGGER_STRICT environment variable is true.
+func (b *SwaggerDocBuilder) Strict(strict bool) *SwaggerDocBuilder {
+	b.errorsMux.Lock()
+	defer b.errorsMux.Unlock()
+	b.strict = strict
+	return b
+}
+
+// Errors returns the errors raised by the builder chains so far, such as a
+// SchemaFromDTO call with a type that cannot be described.
+func (b *SwaggerDocBuilder) Errors() []error {
+	b.errorsMux.Lock()
+	defer b.errorsMux.Unlock()
+	return append([]error(nil), b.errors...)
+}
+
+// BuildChecked builds the document, failing when the builder chains raised
+// errors.
+func (b *SwaggerDocBuilder) BuildChecked() (entity2.SwaggerDocEntity, error) {
+	if err := errors.Join(b.Errors()...); err != nil {
+		return entity2.SwaggerDocEntity{}, err
+	}
+	return b.Build(), nil
+}
+
+func (b *SwaggerDocBuilder) reportError(location string, err error) {
+	err = &BuilderError{Location: location, Err: err}
+	b.errorsMux.Lock()
+	defer b.errorsMux.Unlock()
+	if b.strict {
+		panic(err)
+	}
+	b.errors = append(b.errors, err)
+}
+
+// SchemaFromDTO registers the definition of a DTO and returns the schema that
+// references it. Slices of DTOs, or pointers to them, are described as
+// arrays of references.
+func (b *SwaggerDocBuilder) SchemaFromDTO(dto interface{}) (entity2.SchemaEntity, error) {
+	dtoType := reflect.TypeOf(dto)
+	if dtoType == nil {
+		return entity2.SchemaEntity{}, fmt.Errorf("DTO must not be nil")
+	}
+	if dtoType.Kind() == reflect.Ptr && dtoType.Elem().Kind() == reflect.Slice {
+		dtoType = dtoType.Elem()
+	}
+	if dtoType.Kind() == reflect.Slice || dtoType.Kind() == reflect.Array {
+		elemType := dtoType.Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		dtoName, err := b.DefinitionFromDTO(reflect.New(elemType).Interface())
+		if err != nil {
+			return entity2.SchemaEntity{}, fmt.Errorf("array element: %w", err)
+		}
+		return entity2.SchemaEntity{
+			Type:  "array",
+			Items: &entity2.SchemaEntity{Ref: "#/definitions/" + dtoName},
+		}, nil
+	}
+	dtoName, err := b.DefinitionFromDTO(dto)
+	if err != nil {
+		return entity2.SchemaEntity{}, err
+	}
+	return entity2.SchemaEntity{Ref: "#/definitions/" + dtoName}, nil
+}
@@ -0,0 +1,65 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// SchemaFromType returns the schema of a Go type, registering the
+// definitions of the structs it uses. Structs are described by reference,
+// slices and arrays as arrays and maps as objects with additionalProperties,
+// at any depth, e.g. map[string][]*User.
+func (b *SwaggerDocBuilder) SchemaFromType(t reflect.Type) (entity2.SchemaEntity, error) {
+	if t == nil {
+		return entity2.SchemaEntity{}, fmt.Errorf("type must not be nil")
+	}
+	b.definitionsMux.Lock()
+	defer b.definitionsMux.Unlock()
+	if b.doc.Definitions == nil {
+		b.doc.Definitions = make(map[string]entity2.SchemaEntity)
+	}
+	schema, err := b.GenerateSchemaFromGoType(t, make(map[string]bool))
+	if err != nil {
+		return entity2.SchemaEntity{}, fmt.Errorf("failed to generate schema for %s: %w", t, err)
+	}
+	return *schema, nil
+}
+
+// SchemaOf returns the schema of T, see SwaggerDocBuilder.SchemaFromType:
+//
+//	schema, err := swagger.SchemaOf[[]User](doc)
+func SchemaOf[T any](doc *SwaggerDocBuilder) (entity2.SchemaEntity, error) {
+	return doc.SchemaFromType(reflect.TypeFor[T]())
+}
+
+// DefinitionOf registers the definition of the struct T, or of the struct T
+// points to, and returns its name.
+func DefinitionOf[T any](doc openapi2.SwaggerDoc) (string, error) {
+	if t := reflect.TypeFor[T](); t.Kind() == reflect.Interface {
+		return "", fmt.Errorf("DTO must be a struct or pointer to struct, got %s", t)
+	}
+	var zero T
+	return doc.DefinitionFromDTO(zero)
+}
+
+// ResponseOf sets the schema of a response to the one of T, so arrays and
+// maps are written as types instead of instances:
+//
+//	op.Response(http.StatusOK, func(r openapi.Response) {
+//		swagger.ResponseOf[[]User](r.Description("Users"))
+//	})
+func ResponseOf[T any](r openapi2.Response) openapi2.Response {
+	return r.SchemaFromType(reflect.TypeFor[T]())
+}
+
+// BodyOf sets the schema of a body parameter to the one of T:
+//
+//	op.BodyParameter(func(p openapi.Parameter) {
+//		swagger.BodyOf[CreateUserRequest](p.Required(true))
+//	})
+func BodyOf[T any](p openapi2.Parameter) openapi2.Parameter {
+	return p.SchemaFromType(reflect.TypeFor[T]())
+}
@@ -11,6 +11,7 @@ type OperationBuilder struct {
 	operation   *entity2.OperationEntity
 	pathBuilder *PathItemBuilder
 	docBuilder  *SwaggerDocBuilder
+	location    string // e.g. "GET /pet/{petId}", for error messages
 }
 
 func (b *OperationBuilder) Summary(summary string) openapi2.Operation {
@@ -59,7 +60,7 @@ func (b *OperationBuilder) Parameter(name, in string, config func(builder openap
 	if in == "path" {
 		param.Required = true
 	}
-	paramBuilder := &ParameterBuilder{param: &param, docBuilder: b.docBuilder}
+	paramBuilder := &ParameterBuilder{param: &param, docBuilder: b.docBuilder, location: b.location + " parameter " + name}
 	config(paramBuilder)
 	b.operation.Parameters = append(b.operation.Parameters, param)
 	return b
@@ -89,7 +90,7 @@ func (b *OperationBuilder) Response(statusCode int, config func(builder openapi2
 	if b.operation.Responses == nil {
 		b.operation.Responses = make(map[string]entity2.ResponseEntity)
 	}
-	responseBuilder := &ResponseBuilder{response: &resp, docBuilder: b.docBuilder}
+	responseBuilder := &ResponseBuilder{response: &resp, docBuilder: b.docBuilder, location: b.location + " response " + strconv.Itoa(statusCode)}
 	config(responseBuilder)
 	b.operation.Responses[strconv.Itoa(statusCode)] = resp
 	return b
@@ -113,6 +114,13 @@ func (b *OperationBuilder) ExternalDocumentation(url string, description string)
 	b.operation.ExternalDocs = &entity2.ExternalDocumentationEntity{URL: url, Description: description}
 	return b
 }
+
+// Handler records the function that serves the operation, whose doc comment
+// becomes the Summary and Description when DocComments are set.
+func (b *OperationBuilder) Handler(handler interface{}) openapi2.Operation {
+	b.docBuilder.recordHandler(b.operation, handler)
+	return b
+}
 func (b *OperationBuilder) Path() openapi2.PathItem {
 	return b.pathBuilder
 }
@@ -2,6 +2,8 @@ package swagger
 
 import (
 	"fmt"
+	"reflect"
+
 	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
 	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
 )
@@ -9,6 +11,7 @@ import (
 type ParameterBuilder struct {
 	param      *entity2.ParameterEntity
 	docBuilder *SwaggerDocBuilder
+	location   string
 }
 
 func (b *ParameterBuilder) Description(description string) openapi2.Parameter {
@@ -23,14 +26,28 @@ func (b *ParameterBuilder) Schema(s entity2.SchemaEntity) openapi2.Parameter {
 	b.param.Schema = &s
 	return b
 }
+
+// SchemaFromDTO sets the schema of the parameter from a DTO. Errors are
+// collected on the document, see SwaggerDocBuilder.Errors.
 func (b *ParameterBuilder) SchemaFromDTO(dto interface{}) openapi2.Parameter {
-	dtoName, err := b.docBuilder.DefinitionFromDTO(dto)
+	schema, err := b.docBuilder.SchemaFromDTO(dto)
+	if err != nil {
+		b.docBuilder.reportError(b.location, err)
+		return b
+	}
+	b.param.Schema = &schema
+	return b
+}
+
+// SchemaFromType sets the schema of the parameter from a Go type, see
+// SwaggerDocBuilder.SchemaFromType and BodyOf.
+func (b *ParameterBuilder) SchemaFromType(t reflect.Type) openapi2.Parameter {
+	schema, err := b.docBuilder.SchemaFromType(t)
 	if err != nil {
-		// Consider logging or returning error in a real application
-		fmt.Printf("Error adding DTO definition for parameter schema: %v\n", err)
+		b.docBuilder.reportError(b.location, err)
 		return b
 	}
-	b.param.Schema = &entity2.SchemaEntity{Ref: "#/definitions/" + dtoName}
+	b.param.Schema = &schema
 	return b
 }
 func (b *ParameterBuilder) Type(paramType string) openapi2.Parameter {
@@ -96,6 +113,28 @@ func (b *ParameterBuilder) UniqueItems(unique bool) openapi2.Parameter {
 }
 func (b *ParameterBuilder) Enum(values ...interface{}) openapi2.Parameter {
 	b.param.Enum = values
+	b.param.EnumVarNames, b.param.EnumDescriptions = nil, nil
+	return b
+}
+
+// TypeOf sets the type, format, items and enum of a non-body parameter from
+// a Go type, see ParamOf. Structs cannot be described without a schema and
+// are reported as errors.
+func (b *ParameterBuilder) TypeOf(t reflect.Type) openapi2.Parameter {
+	schema, err := b.docBuilder.SchemaFromType(t)
+	if err == nil && (schema.Ref != "" || schema.Type == "object") {
+		err = fmt.Errorf("%s parameters cannot be objects, got %s", b.param.In, t)
+	}
+	if err != nil {
+		b.docBuilder.reportError(b.location, err)
+		return b
+	}
+	b.param.Type = schema.Type
+	b.param.Format = schema.Format
+	b.param.Items = schema.Items
+	b.param.Enum = schema.Enum
+	b.param.EnumVarNames = schema.EnumVarNames
+	b.param.EnumDescriptions = schema.EnumDescriptions
 	return b
 }
 func (b *ParameterBuilder) MultipleOf(val float64) openapi2.Parameter {
@@ -11,6 +11,7 @@ type PathItemBuilder struct {
 	pathItem   *entity2.PathItemEntity
 	docPath    string
 	docBuilder *SwaggerDocBuilder
+	paths      map[string]entity2.PathItemEntity // doc.Paths, or doc.Webhooks for webhooks
 }
 
 func (b *PathItemBuilder) operation(method string, config func(builder openapi2.Operation)) openapi2.PathItem {
@@ -18,7 +19,7 @@ func (b *PathItemBuilder) operation(method string, config func(builder openapi2.
 		Responses:  make(map[string]entity2.ResponseEntity),
 		Parameters: make([]entity2.ParameterEntity, 0),
 	}
-	opBuilder := &OperationBuilder{operation: op, pathBuilder: b, docBuilder: b.docBuilder}
+	opBuilder := &OperationBuilder{operation: op, pathBuilder: b, docBuilder: b.docBuilder, location: strings.ToUpper(method) + " " + b.docPath}
 	config(opBuilder)
 
 	switch strings.ToUpper(method) {
@@ -37,7 +38,7 @@ func (b *PathItemBuilder) operation(method string, config func(builder openapi2.
 	case http.MethodPatch:
 		b.pathItem.Patch = op
 	}
-	b.docBuilder.doc.Paths[b.docPath] = *b.pathItem
+	b.paths[b.docPath] = *b.pathItem
 	return b
 }
 
@@ -68,14 +69,14 @@ func (b *PathItemBuilder) Parameter(name, in string, config func(builder openapi
 	if in == "path" {
 		param.Required = true
 	}
-	paramBuilder := &ParameterBuilder{param: &param, docBuilder: b.docBuilder}
+	paramBuilder := &ParameterBuilder{param: &param, docBuilder: b.docBuilder, location: b.docPath + " parameter " + name}
 	config(paramBuilder)
 	b.pathItem.Parameters = append(b.pathItem.Parameters, param)
-	b.docBuilder.doc.Paths[b.docPath] = *b.pathItem
+	b.paths[b.docPath] = *b.pathItem
 	return b
 }
 
 func (b *PathItemBuilder) Doc() openapi2.SwaggerDoc {
-	b.docBuilder.doc.Paths[b.docPath] = *b.pathItem
+	b.paths[b.docPath] = *b.pathItem
 	return b.docBuilder
 }
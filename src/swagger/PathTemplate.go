@@ -0,0 +1,175 @@
+package swagger
+
+import (
+	"strings"
+
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// FromGinPath translates gin's ":param" and "*wildcard" segments into Swagger
+// "{param}" templates: "/pet/:petId" becomes "/pet/{petId}". Paths already in
+// template syntax are returned unchanged.
+func FromGinPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// ToGinPath translates Swagger "{param}" segments into gin ":param" segments.
+func ToGinPath(template string) string {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if name, ok := templateParam(segment); ok {
+			segments[i] = ":" + name
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// FromServeMuxPattern splits a Go 1.22 http.ServeMux pattern such as
+// "GET example.com/pets/{id}" into its method, empty when the pattern matches
+// every method, and a Swagger path template. The host is dropped, "{rest...}"
+// becomes "{rest}" and the "{$}" end anchor is removed.
+func FromServeMuxPattern(pattern string) (method, path string) {
+	pattern = strings.TrimSpace(pattern)
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		method, pattern = pattern[:i], strings.TrimLeft(pattern[i:], " \t")
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:] // host
+	}
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if segment == "{$}" {
+			segments[i] = ""
+		} else if name, ok := templateParam(segment); ok {
+			segments[i] = "{" + strings.TrimSuffix(name, "...") + "}"
+		}
+	}
+	return method, strings.Join(segments, "/")
+}
+
+// StripBasePath removes basePath from the start of path, so a full route like
+// "/v2/pet/{petId}" becomes "/pet/{petId}" for basePath "/v2". Paths outside
+// basePath are returned unchanged.
+func StripBasePath(path, basePath string) string {
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath == "" || (path != basePath && !strings.HasPrefix(path, basePath+"/")) {
+		return path
+	}
+	path = strings.TrimPrefix(path, basePath)
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// GinPathToTemplate converts a full gin route path into a Swagger path
+// template relative to basePath.
+func GinPathToTemplate(path, basePath string) string {
+	return StripBasePath(FromGinPath(path), basePath)
+}
+
+// PathParameterNames returns the names of the "{param}" segments of a template.
+func PathParameterNames(template string) []string {
+	var names []string
+	for _, segment := range strings.Split(template, "/") {
+		if name, ok := templateParam(segment); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func templateParam(segment string) (string, bool) {
+	if len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// relativePaths re-keys paths registered with their full route (including the
+// BasePath) by their relative path in routePaths, merging them with paths
+// registered relative.
+func relativePaths(paths map[string]entity2.PathItemEntity, routePaths map[string]string) map[string]entity2.PathItemEntity {
+	result := make(map[string]entity2.PathItemEntity, len(paths))
+	for path, item := range paths {
+		key := path
+		if relative, ok := routePaths[path]; ok {
+			key = relative
+		}
+		if existing, ok := result[key]; ok {
+			item = mergePathItems(existing, item)
+		}
+		result[key] = withPathParameters(key, item)
+	}
+	return result
+}
+
+func mergePathItems(a, b entity2.PathItemEntity) entity2.PathItemEntity {
+	pick := func(x, y *entity2.OperationEntity) *entity2.OperationEntity {
+		if x != nil {
+			return x
+		}
+		return y
+	}
+	a.Get = pick(a.Get, b.Get)
+	a.Post = pick(a.Post, b.Post)
+	a.Put = pick(a.Put, b.Put)
+	a.Delete = pick(a.Delete, b.Delete)
+	a.Options = pick(a.Options, b.Options)
+	a.Head = pick(a.Head, b.Head)
+	a.Patch = pick(a.Patch, b.Patch)
+	a.Parameters = append(append([]entity2.ParameterEntity{}, a.Parameters...), b.Parameters...)
+	if a.Ref == "" {
+		a.Ref = b.Ref
+	}
+	return a
+}
+
+// withPathParameters adds a required string parameter to every operation for
+// each template parameter that was not declared with PathParameter.
+func withPathParameters(template string, item entity2.PathItemEntity) entity2.PathItemEntity {
+	names := PathParameterNames(template)
+	if len(names) == 0 {
+		return item
+	}
+	complete := func(op *entity2.OperationEntity) *entity2.OperationEntity {
+		if op == nil {
+			return nil
+		}
+		var missing []entity2.ParameterEntity
+		for _, name := range names {
+			if !hasPathParameter(op.Parameters, name) && !hasPathParameter(item.Parameters, name) {
+				missing = append(missing, entity2.ParameterEntity{Name: name, In: "path", Required: true, Type: "string"})
+			}
+		}
+		if len(missing) == 0 {
+			return op
+		}
+		completed := *op
+		completed.Parameters = append(append([]entity2.ParameterEntity{}, op.Parameters...), missing...)
+		return &completed
+	}
+	item.Get = complete(item.Get)
+	item.Post = complete(item.Post)
+	item.Put = complete(item.Put)
+	item.Delete = complete(item.Delete)
+	item.Options = complete(item.Options)
+	item.Head = complete(item.Head)
+	item.Patch = complete(item.Patch)
+	return item
+}
+
+func hasPathParameter(params []entity2.ParameterEntity, name string) bool {
+	for _, param := range params {
+		if param.In == "path" && param.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package swagger
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+func TestFromGinPath(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"/pet/:petId", "/pet/{petId}"},
+		{"/pet/:petId/photos/:photoId", "/pet/{petId}/photos/{photoId}"},
+		{"/static/*filepath", "/static/{filepath}"},
+		{"/pet/{petId}", "/pet/{petId}"},
+		{"/pet/findByStatus", "/pet/findByStatus"},
+		{"/", "/"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := FromGinPath(tt.path); got != tt.want {
+			t.Errorf("FromGinPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestToGinPath(t *testing.T) {
+	tests := []struct {
+		template, want string
+	}{
+		{"/pet/{petId}", "/pet/:petId"},
+		{"/pet/{petId}/photos/{photoId}", "/pet/:petId/photos/:photoId"},
+		{"/pet/:petId", "/pet/:petId"},
+		{"/pet/{}", "/pet/{}"},
+		{"/report.{format}", "/report.{format}"},
+	}
+	for _, tt := range tests {
+		if got := ToGinPath(tt.template); got != tt.want {
+			t.Errorf("ToGinPath(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestFromServeMuxPattern(t *testing.T) {
+	tests := []struct {
+		pattern, method, path string
+	}{
+		{"GET /pets/{id}", "GET", "/pets/{id}"},
+		{"/pets/{id}", "", "/pets/{id}"},
+		{"POST example.com/pets", "POST", "/pets"},
+		{"GET /files/{path...}", "GET", "/files/{path}"},
+		{"GET /{$}", "GET", "/"},
+		{"  DELETE \t/pets/{id}/", "DELETE", "/pets/{id}/"},
+	}
+	for _, tt := range tests {
+		method, path := FromServeMuxPattern(tt.pattern)
+		if method != tt.method || path != tt.path {
+			t.Errorf("FromServeMuxPattern(%q) = %q, %q, want %q, %q", tt.pattern, method, path, tt.method, tt.path)
+		}
+	}
+}
+
+func TestStripBasePath(t *testing.T) {
+	tests := []struct {
+		path, basePath, want string
+	}{
+		{"/v2/pet/{petId}", "/v2", "/pet/{petId}"},
+		{"/v2/pet/{petId}", "/v2/", "/pet/{petId}"},
+		{"/v2", "/v2", "/"},
+		{"/v2/", "/v2", "/"},
+		{"/v20/pet", "/v2", "/v20/pet"},
+		{"/pet", "/v2", "/pet"},
+		{"/pet", "", "/pet"},
+		{"/pet", "/", "/pet"},
+	}
+	for _, tt := range tests {
+		if got := StripBasePath(tt.path, tt.basePath); got != tt.want {
+			t.Errorf("StripBasePath(%q, %q) = %q, want %q", tt.path, tt.basePath, got, tt.want)
+		}
+	}
+}
+
+func TestGinPathToTemplate(t *testing.T) {
+	if got := GinPathToTemplate("/v2/pet/:petId/*rest", "/v2"); got != "/pet/{petId}/{rest}" {
+		t.Errorf("GinPathToTemplate = %q, want /pet/{petId}/{rest}", got)
+	}
+}
+
+func TestPathParameterNames(t *testing.T) {
+	tests := []struct {
+		template string
+		want     []string
+	}{
+		{"/pet/{petId}/photos/{photoId}", []string{"petId", "photoId"}},
+		{"/pet/:petId", nil},
+		{"/pet", nil},
+	}
+	for _, tt := range tests {
+		if got := PathParameterNames(tt.template); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PathParameterNames(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestBuildRelativePaths(t *testing.T) {
+	getOp := func(op openapi2.Operation) {
+		op.Response(200, func(r openapi2.Response) { r.Description("OK") })
+	}
+	tests := []struct {
+		name     string
+		register func(doc *SwaggerDocBuilder)
+		want     []string
+	}{
+		{
+			name: "full route after the base path",
+			register: func(doc *SwaggerDocBuilder) {
+				doc.BasePath("/v2")
+				doc.Path("/v2/pet/:petId").Get(getOp)
+			},
+			want: []string{"/pet/{petId}"},
+		},
+		{
+			name: "relative path after the base path",
+			register: func(doc *SwaggerDocBuilder) {
+				doc.BasePath("/v2")
+				doc.Path("/pet/{petId}").Get(getOp)
+			},
+			want: []string{"/pet/{petId}"},
+		},
+		{
+			name: "path registered before the base path",
+			register: func(doc *SwaggerDocBuilder) {
+				doc.Path("/api/keys").Get(getOp)
+				doc.BasePath("/api")
+			},
+			want: []string{"/api/keys"},
+		},
+		{
+			name: "path repeating the base path",
+			register: func(doc *SwaggerDocBuilder) {
+				doc.BasePath("/api")
+				doc.Path("/api/api/keys").Get(getOp)
+			},
+			want: []string{"/api/keys"},
+		},
+		{
+			name: "base path root",
+			register: func(doc *SwaggerDocBuilder) {
+				doc.BasePath("/v2")
+				doc.Path("/v2").Get(getOp)
+			},
+			want: []string{"/"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New()
+			tt.register(doc)
+			built := doc.Build()
+			var paths []string
+			for path := range built.Paths {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			if !reflect.DeepEqual(paths, tt.want) {
+				t.Errorf("paths %q, want %q", paths, tt.want)
+			}
+			if violations := doc.Validate(); len(violations) > 0 {
+				t.Errorf("unexpected violations: %v", violations)
+			}
+		})
+	}
+}
+
+func TestBuildRelativePathsMergesOperations(t *testing.T) {
+	doc := New()
+	doc.BasePath("/v2")
+	doc.Path("/v2/pet/:petId").Get(func(op openapi2.Operation) {
+		op.Response(200, func(r openapi2.Response) { r.Description("OK") })
+	})
+	doc.Path("/pet/{petId}").Delete(func(op openapi2.Operation) {
+		op.Response(204, func(r openapi2.Response) { r.Description("Deleted") })
+	})
+	item := doc.Build().Paths["/pet/{petId}"]
+	if item.Get == nil || item.Delete == nil {
+		t.Fatalf("path item %+v, want get and delete", item)
+	}
+	for method, op := range map[string]*entity2.OperationEntity{"get": item.Get, "delete": item.Delete} {
+		if len(op.Parameters) != 1 || op.Parameters[0].Name != "petId" || op.Parameters[0].In != "path" || !op.Parameters[0].Required {
+			t.Errorf("%s parameters %+v, want the required petId path parameter", method, op.Parameters)
+		}
+	}
+}
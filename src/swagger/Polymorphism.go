@@ -0,0 +1,234 @@
+package swagger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// Polymorphic registers base, an interface or a struct, as a polymorphic
+// type whose concrete types are told apart by the discriminator property.
+// implementations maps each discriminator value to an instance of a struct,
+// or pointer to struct, implementing base. Implementations must encode the
+// discriminator property and, for a struct base, every property of base,
+// usually by embedding it:
+//
+//	doc.Polymorphic(reflect.TypeFor[Shape](), "kind", map[string]interface{}{
+//		"Circle": Circle{},
+//		"Square": Square{},
+//	})
+//
+// Swagger 2.0 takes the discriminator value as the definition name, so each
+// implementation is defined under its value, as an allOf of the base
+// definition and its own properties. The base definition declares the
+// discriminator, and fields typed as an interface base refer to it. Register
+// polymorphic types before the DTOs using them.
+func (b *SwaggerDocBuilder) Polymorphic(base reflect.Type, discriminator string, implementations map[string]interface{}) *SwaggerDocBuilder {
+	location := "definitions"
+	if base == nil {
+		b.reportError(location, fmt.Errorf("polymorphic base must not be nil"))
+		return b
+	}
+	if base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+	// Check the base before naming it, which reserves the name for good
+	if (base.Kind() != reflect.Interface && base.Kind() != reflect.Struct) || base.Name() == "" {
+		b.reportError(location, fmt.Errorf("polymorphic base must be a named interface or struct, got %s", base))
+		return b
+	}
+	if discriminator == "" {
+		b.reportError(location, fmt.Errorf("%s: discriminator must not be empty", typeString(base)))
+		return b
+	}
+
+	b.definitionsMux.Lock()
+	defer b.definitionsMux.Unlock()
+	if b.doc.Definitions == nil {
+		b.doc.Definitions = make(map[string]entity2.SchemaEntity)
+	}
+
+	baseName := b.definitionNameFor(base)
+	location += "." + baseName
+	if _, exists := b.doc.Definitions[baseName]; exists {
+		b.reportError(location, fmt.Errorf("%s is already defined, register polymorphic types before using them", typeString(base)))
+		return b
+	}
+	if b.discriminators == nil {
+		b.discriminators = make(map[reflect.Type]string)
+		b.implementationOf = make(map[reflect.Type]reflect.Type)
+	}
+	b.discriminators[base] = discriminator
+
+	values := make([]string, 0, len(implementations))
+	for value := range implementations {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	registered := make(map[string]reflect.Type, len(values))
+	for _, value := range values {
+		t := reflect.TypeOf(implementations[value])
+		if t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch {
+		case t == nil || t.Kind() != reflect.Struct:
+			b.reportError(location, fmt.Errorf("implementation %q must be a struct or pointer to struct, got %v", value, t))
+			continue
+		case base.Kind() == reflect.Interface && !t.Implements(base) && !reflect.PointerTo(t).Implements(base):
+			b.reportError(location, fmt.Errorf("implementation %q: %s does not implement %s", value, typeString(t), typeString(base)))
+			continue
+		}
+		if missing := missingProperties(t, base, discriminator); len(missing) > 0 {
+			b.reportError(location, fmt.Errorf("implementation %q: %s does not encode the properties %q of %s", value, typeString(t), missing, typeString(base)))
+			continue
+		}
+		if name, named := b.typeNames[t]; named && name != value {
+			b.reportError(location, fmt.Errorf("implementation %q: %s is already defined as %q, register polymorphic types before using them", value, typeString(t), name))
+			continue
+		}
+		if owner, taken := b.definitionTypes[value]; taken && owner != t {
+			b.reportError(location, fmt.Errorf("implementation %q: the name is already used by %s", value, typeString(owner)))
+			continue
+		}
+		b.nameType(t, value)
+		b.implementationOf[t] = base
+		registered[value] = t
+	}
+
+	if _, err := b.GenerateSchemaFromGoType(base, make(map[string]bool)); err != nil {
+		b.reportError(location, err)
+		return b
+	}
+	for _, value := range values {
+		t, ok := registered[value]
+		if !ok {
+			continue
+		}
+		if _, err := b.GenerateSchemaFromGoType(t, make(map[string]bool)); err != nil {
+			b.reportError(location, fmt.Errorf("implementation %q: %w", value, err))
+		}
+	}
+	return b
+}
+
+// PolymorphicOf registers the interface or struct T as a polymorphic type,
+// see SwaggerDocBuilder.Polymorphic:
+//
+//	swagger.PolymorphicOf[Shape](doc, "kind", map[string]interface{}{
+//		"Circle": Circle{},
+//		"Square": Square{},
+//	})
+func PolymorphicOf[T any](doc *SwaggerDocBuilder, discriminator string, implementations map[string]interface{}) *SwaggerDocBuilder {
+	return doc.Polymorphic(reflect.TypeFor[T](), discriminator, implementations)
+}
+
+// interfaceSchema returns the reference to the definition of a polymorphic
+// interface, creating it with only the discriminator property.
+func (b *SwaggerDocBuilder) interfaceSchema(t reflect.Type) (*entity2.SchemaEntity, error) {
+	discriminator, ok := b.discriminators[t]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type for DTO schema generation: interface %s, register it with Polymorphic", t)
+	}
+	name := b.definitionNameFor(t)
+	if _, exists := b.doc.Definitions[name]; !exists {
+		b.doc.Definitions[name] = entity2.SchemaEntity{
+			Type:          "object",
+			Discriminator: discriminator,
+			Required:      []string{discriminator},
+			Properties: map[string]*entity2.SchemaEntity{
+				discriminator: {Type: "string"},
+			},
+		}
+		b.recordDefinition(name, t, nil)
+	}
+	return &entity2.SchemaEntity{Ref: "#/definitions/" + name}, nil
+}
+
+// polymorphicSchema adapts the schema of a struct registered with
+// Polymorphic: a base declares the discriminator and an implementation
+// becomes an allOf of its base and its own properties.
+func (b *SwaggerDocBuilder) polymorphicSchema(t reflect.Type, schema entity2.SchemaEntity, fieldSources map[string]fieldSource) entity2.SchemaEntity {
+	if discriminator, ok := b.discriminators[t]; ok {
+		schema.Discriminator = discriminator
+		if schema.Properties == nil {
+			schema.Properties = make(map[string]*entity2.SchemaEntity)
+		}
+		if schema.Properties[discriminator] == nil {
+			schema.Properties[discriminator] = &entity2.SchemaEntity{Type: "string"}
+		}
+		if !containsString(schema.Required, discriminator) {
+			schema.Required = append(schema.Required, discriminator)
+		}
+	}
+
+	base, ok := b.implementationOf[t]
+	if !ok {
+		return schema
+	}
+	baseRef := &entity2.SchemaEntity{Ref: "#/definitions/" + b.definitionNameFor(base)}
+	if len(schema.AllOf) > 0 { // EmbeddedAllOf, the base may be embedded already
+		allOf := []*entity2.SchemaEntity{baseRef}
+		for _, part := range schema.AllOf {
+			if part.Ref != baseRef.Ref {
+				allOf = append(allOf, part)
+			}
+		}
+		return entity2.SchemaEntity{AllOf: allOf}
+	}
+
+	// Leave out the fields promoted from an embedded base, the base
+	// definition already has them
+	baseProperties := b.doc.Definitions[b.definitionNameFor(base)].Properties
+	own := schema
+	own.Properties = make(map[string]*entity2.SchemaEntity, len(schema.Properties))
+	own.Required = nil
+	for name, prop := range schema.Properties {
+		if _, inBase := baseProperties[name]; !inBase || fieldSources[name].owner == t {
+			own.Properties[name] = prop
+		}
+	}
+	for _, name := range schema.Required {
+		if _, kept := own.Properties[name]; kept {
+			own.Required = append(own.Required, name)
+		}
+	}
+	return entity2.SchemaEntity{AllOf: []*entity2.SchemaEntity{baseRef, &own}}
+}
+
+// missingProperties returns the properties an implementation t of base
+// must encode, the discriminator and the fields of a struct base, that
+// encoding/json leaves out of t.
+func missingProperties(t, base reflect.Type, discriminator string) []string {
+	encoded := make(map[string]bool)
+	for _, f := range jsonFields(t) {
+		encoded[f.name] = true
+	}
+	required := []string{discriminator}
+	if base.Kind() == reflect.Struct {
+		for _, f := range jsonFields(base) {
+			if f.name != discriminator {
+				required = append(required, f.name)
+			}
+		}
+	}
+	var missing []string
+	for _, name := range required {
+		if !encoded[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
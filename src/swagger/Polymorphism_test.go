@@ -0,0 +1,184 @@
+package swagger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type polyShape interface{ Area() float64 }
+
+type polyCircle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (c polyCircle) Area() float64 { return 3 * c.Radius * c.Radius }
+
+type polySquare struct {
+	Kind string  `json:"kind"`
+	Side float64 `json:"side"`
+}
+
+func (s *polySquare) Area() float64 { return s.Side * s.Side }
+
+// polyBlob implements polyShape without encoding the discriminator.
+type polyBlob struct {
+	Size float64 `json:"size"`
+}
+
+func (b polyBlob) Area() float64 { return b.Size }
+
+type polyDrawing struct {
+	Shapes []polyShape `json:"shapes"`
+}
+
+type polyAnimal struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type polyDog struct {
+	polyAnimal
+	Bark bool `json:"bark"`
+}
+
+// polyCat encodes every property of polyAnimal without embedding it.
+type polyCat struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Lives int    `json:"lives"`
+}
+
+// polyFish lacks the name of polyAnimal.
+type polyFish struct {
+	Type string `json:"type"`
+}
+
+func builderErrors(doc *SwaggerDocBuilder) []string {
+	var errs []string
+	for _, err := range doc.Errors() {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+func definitionJSON(t *testing.T, doc *SwaggerDocBuilder, name string) string {
+	t.Helper()
+	definition, ok := doc.Build().Definitions[name]
+	if !ok {
+		t.Fatalf("no definition %s", name)
+	}
+	data, err := json.Marshal(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestPolymorphicInterface(t *testing.T) {
+	doc := New()
+	PolymorphicOf[polyShape](doc, "kind", map[string]interface{}{
+		"Circle": polyCircle{},
+		"Square": &polySquare{},
+	})
+	if _, err := doc.SchemaFromType(reflect.TypeFor[polyDrawing]()); err != nil {
+		t.Fatal(err)
+	}
+	if errs := builderErrors(doc); len(errs) > 0 {
+		t.Fatalf("unexpected errors %q", errs)
+	}
+	want := map[string]string{
+		"polyShape":   `{"type":"object","discriminator":"kind","required":["kind"],"properties":{"kind":{"type":"string"}}}`,
+		"Circle":      `{"allOf":[{"$ref":"#/definitions/polyShape"},{"type":"object","required":["kind","radius"],"properties":{"kind":{"type":"string"},"radius":{"type":"number","format":"double"}}}]}`,
+		"Square":      `{"allOf":[{"$ref":"#/definitions/polyShape"},{"type":"object","required":["kind","side"],"properties":{"kind":{"type":"string"},"side":{"type":"number","format":"double"}}}]}`,
+		"polyDrawing": `{"type":"object","required":["shapes"],"properties":{"shapes":{"type":"array","items":{"$ref":"#/definitions/polyShape"}}}}`,
+	}
+	for name, schema := range want {
+		if got := definitionJSON(t, doc, name); !sameJSON(got, schema) {
+			t.Errorf("%s: %s, want %s", name, got, schema)
+		}
+	}
+}
+
+func TestPolymorphicStruct(t *testing.T) {
+	doc := New()
+	PolymorphicOf[polyAnimal](doc, "type", map[string]interface{}{
+		"Dog": polyDog{},
+		"Cat": polyCat{},
+	})
+	if errs := builderErrors(doc); len(errs) > 0 {
+		t.Fatalf("unexpected errors %q", errs)
+	}
+	want := map[string]string{
+		"polyAnimal": `{"type":"object","discriminator":"type","required":["type","name"],"properties":{"type":{"type":"string"},"name":{"type":"string"}}}`,
+		// the embedded fields are left to the base definition
+		"Dog": `{"allOf":[{"$ref":"#/definitions/polyAnimal"},{"type":"object","required":["bark"],"properties":{"bark":{"type":"boolean"}}}]}`,
+		"Cat": `{"allOf":[{"$ref":"#/definitions/polyAnimal"},{"type":"object","required":["type","name","lives"],"properties":{"type":{"type":"string"},"name":{"type":"string"},"lives":{"type":"integer","format":"int32"}}}]}`,
+	}
+	for name, schema := range want {
+		if got := definitionJSON(t, doc, name); !sameJSON(got, schema) {
+			t.Errorf("%s: %s, want %s", name, got, schema)
+		}
+	}
+}
+
+func TestPolymorphicErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		base            reflect.Type
+		discriminator   string
+		implementations map[string]interface{}
+		want            []string
+	}{
+		{
+			name:          "not an implementation",
+			base:          reflect.TypeFor[polyShape](),
+			discriminator: "kind",
+			implementations: map[string]interface{}{
+				"Circle": polyCircle{},
+				"Dog":    polyDog{},
+			},
+			want: []string{`definitions.polyShape: implementation "Dog": github.com/ruiborda/go-swagger-generator/src/swagger.polyDog does not implement github.com/ruiborda/go-swagger-generator/src/swagger.polyShape`},
+		},
+		{
+			name:            "implementation without the discriminator",
+			base:            reflect.TypeFor[polyShape](),
+			discriminator:   "kind",
+			implementations: map[string]interface{}{"Blob": polyBlob{}},
+			want:            []string{`definitions.polyShape: implementation "Blob": github.com/ruiborda/go-swagger-generator/src/swagger.polyBlob does not encode the properties ["kind"] of github.com/ruiborda/go-swagger-generator/src/swagger.polyShape`},
+		},
+		{
+			name:            "struct implementation missing base properties",
+			base:            reflect.TypeFor[polyAnimal](),
+			discriminator:   "type",
+			implementations: map[string]interface{}{"Fish": polyFish{}, "Circle": polyCircle{}},
+			want: []string{
+				`definitions.polyAnimal: implementation "Circle": github.com/ruiborda/go-swagger-generator/src/swagger.polyCircle does not encode the properties ["type" "name"] of github.com/ruiborda/go-swagger-generator/src/swagger.polyAnimal`,
+				`definitions.polyAnimal: implementation "Fish": github.com/ruiborda/go-swagger-generator/src/swagger.polyFish does not encode the properties ["name"] of github.com/ruiborda/go-swagger-generator/src/swagger.polyAnimal`,
+			},
+		},
+		{
+			name:            "not a struct",
+			base:            reflect.TypeFor[polyShape](),
+			discriminator:   "kind",
+			implementations: map[string]interface{}{"Number": 1.5},
+			want:            []string{`definitions.polyShape: implementation "Number" must be a struct or pointer to struct, got float64`},
+		},
+		{
+			name:          "empty discriminator",
+			base:          reflect.TypeFor[polyShape](),
+			discriminator: "",
+			want:          []string{`definitions: github.com/ruiborda/go-swagger-generator/src/swagger.polyShape: discriminator must not be empty`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New()
+			doc.Polymorphic(tt.base, tt.discriminator, tt.implementations)
+			if got := builderErrors(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
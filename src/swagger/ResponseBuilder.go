@@ -1,7 +1,6 @@
 package swagger
 
 import (
-	"fmt"
 	"reflect"
 
 	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
@@ -11,6 +10,7 @@ import (
 type ResponseBuilder struct {
 	response   *entity2.ResponseEntity
 	docBuilder *SwaggerDocBuilder
+	location   string
 }
 
 func (b *ResponseBuilder) Description(description string) openapi2.Response {
@@ -21,47 +21,29 @@ func (b *ResponseBuilder) Schema(s entity2.SchemaEntity) openapi2.Response {
 	b.response.Schema = &s
 	return b
 }
-func (b *ResponseBuilder) SchemaFromDTO(dto interface{}) openapi2.Response {
-	// Get the type of the DTO
-	dtoType := reflect.TypeOf(dto)
-
-	// Handle array type responses
-	if dtoType.Kind() == reflect.Ptr && dtoType.Elem().Kind() == reflect.Slice {
-		// It's a pointer to a slice
-		sliceType := dtoType.Elem()
-		elemType := sliceType.Elem() // Get the element type of the slice
-
-		schema := &entity2.SchemaEntity{
-			Type: "array",
-		}
-
-		// If the element is a pointer, get its underlying type
-		if elemType.Kind() == reflect.Ptr {
-			elemType = elemType.Elem()
-		}
-
-		// Only handle struct elements to generate schema
-		if elemType.Kind() == reflect.Struct {
-			dtoName, err := b.docBuilder.DefinitionFromDTO(reflect.New(elemType).Interface())
-			if err != nil {
-				fmt.Printf("Error adding DTO definition for array element in response schema: %v\n", err)
-				return b
-			}
 
-			// Create array schema with items referencing the struct definition
-			schema.Items = &entity2.SchemaEntity{Ref: "#/definitions/" + dtoName}
-			b.response.Schema = schema
-			return b
-		}
+// SchemaFromDTO sets the schema of the response from a DTO; pointers to
+// slices of DTOs are described as arrays. Errors are collected on the
+// document, see SwaggerDocBuilder.Errors.
+func (b *ResponseBuilder) SchemaFromDTO(dto interface{}) openapi2.Response {
+	schema, err := b.docBuilder.SchemaFromDTO(dto)
+	if err != nil {
+		b.docBuilder.reportError(b.location, err)
+		return b
 	}
+	b.response.Schema = &schema
+	return b
+}
 
-	// Original behavior for non-array types
-	dtoName, err := b.docBuilder.DefinitionFromDTO(dto)
+// SchemaFromType sets the schema of the response from a Go type, see
+// SwaggerDocBuilder.SchemaFromType and ResponseOf.
+func (b *ResponseBuilder) SchemaFromType(t reflect.Type) openapi2.Response {
+	schema, err := b.docBuilder.SchemaFromType(t)
 	if err != nil {
-		fmt.Printf("Error adding DTO definition for response schema: %v\n", err)
+		b.docBuilder.reportError(b.location, err)
 		return b
 	}
-	b.response.Schema = &entity2.SchemaEntity{Ref: "#/definitions/" + dtoName}
+	b.response.Schema = &schema
 	return b
 }
 func (b *ResponseBuilder) SchemaRef(ref string) openapi2.Response {
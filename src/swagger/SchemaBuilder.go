@@ -28,6 +28,7 @@ func (b *SchemaBuilder) Description(description string) openapi.Schema {
 }
 func (b *SchemaBuilder) Enum(values ...interface{}) openapi.Schema {
 	b.schema.Enum = values
+	b.schema.EnumVarNames, b.schema.EnumDescriptions = nil, nil
 	return b
 }
 func (b *SchemaBuilder) Default(value interface{}) openapi.Schema {
@@ -13,3 +13,14 @@ func (b *ServerBuilder) Description(description string) openapi.Server {
 	b.server.Description = description
 	return b
 }
+func (b *ServerBuilder) Variable(name string, defaultValue string, description string, enum ...string) openapi.Server {
+	if b.server.Variables == nil {
+		b.server.Variables = make(map[string]openapi_spec.ServerVariableEntity)
+	}
+	b.server.Variables[name] = openapi_spec.ServerVariableEntity{
+		Enum:        enum,
+		Default:     defaultValue,
+		Description: description,
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// Format is the encoding of a written document.
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+)
+
+// FormatOf returns YAML for ".yaml" and ".yml" paths and JSON otherwise.
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return YAML
+	}
+	return JSON
+}
+
+// Marshal encodes doc in the version selected with SwaggerVersion (see
+// BuildSpec) as indented JSON or as YAML.
+func Marshal(doc openapi2.SwaggerDoc, format Format) ([]byte, error) {
+	spec := BuildSpec(doc)
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(spec, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case YAML:
+		return entity2.ToYAML(spec)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// WriteFile writes the default Swagger() document to path. An empty format
+// is chosen from the file extension with FormatOf. Call it from main (for
+// instance behind a flag) once the package-level documentation has been
+// registered, to produce the spec at build time without starting the server.
+func WriteFile(path string, format Format) error {
+	return WriteDocFile(Swagger(), path, format)
+}
+
+// WriteDocFile writes doc to path, like WriteFile.
+func WriteDocFile(doc openapi2.SwaggerDoc, path string, format Format) error {
+	if format == "" {
+		format = FormatOf(path)
+	}
+	data, err := Marshal(doc, format)
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0o644)
+}
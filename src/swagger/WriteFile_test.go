@@ -0,0 +1,118 @@
+package swagger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		path string
+		want Format
+	}{
+		{"openapi.json", JSON},
+		{"openapi.yaml", YAML},
+		{"docs/openapi.YML", YAML},
+		{"openapi", JSON},
+	}
+	for _, tt := range tests {
+		if got := FormatOf(tt.path); got != tt.want {
+			t.Errorf("FormatOf(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// writtenDoc documents a path whose strings YAML would read as other types.
+func writtenDoc() *SwaggerDocBuilder {
+	doc := New()
+	doc.Info(func(info openapi2.Info) { info.Title("Pets").Version("1.0") })
+	doc.Path("/pets").Get(func(op openapi2.Operation) {
+		op.Summary("on").Response(200, func(r openapi2.Response) { r.Description("yes") })
+	})
+	return doc
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		format  Format
+		want    []string
+	}{
+		{"json", "", JSON, []string{`"swagger": "2.0"`, `"summary": "on"`, `"description": "yes"`}},
+		{"yaml", "", YAML, []string{"swagger: \"2.0\"\n", "summary: \"on\"\n", "description: \"yes\"\n", "\"200\":\n"}},
+		{"openapi 3 json", "3.0.3", JSON, []string{`"openapi": "3.0.3"`}},
+		{"openapi 3.1 yaml", "3.1.0", YAML, []string{"openapi: 3.1.0\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := writtenDoc()
+			if tt.version != "" {
+				doc.SwaggerVersion(tt.version)
+			}
+			data, err := Marshal(doc, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("output does not contain %q:\n%s", want, data)
+				}
+			}
+			var decoded map[string]interface{}
+			if tt.format == JSON {
+				err = json.Unmarshal(data, &decoded)
+			} else {
+				err = yaml.Unmarshal(data, &decoded)
+			}
+			if err != nil {
+				t.Fatalf("output does not decode: %v\n%s", err, data)
+			}
+		})
+	}
+	if _, err := Marshal(writtenDoc(), "toml"); err == nil || err.Error() != `unsupported format "toml"` {
+		t.Errorf("unsupported format: error %v", err)
+	}
+}
+
+func TestWriteDocFile(t *testing.T) {
+	dir := t.TempDir()
+	doc := writtenDoc()
+	tests := []struct {
+		path   string
+		format Format
+		prefix string
+	}{
+		{filepath.Join(dir, "openapi.json"), "", "{\n"},
+		{filepath.Join(dir, "nested", "docs", "openapi.yml"), "", "swagger: \"2.0\"\n"},
+		{filepath.Join(dir, "openapi.txt"), YAML, "swagger: \"2.0\"\n"},
+	}
+	for _, tt := range tests {
+		if err := WriteDocFile(doc, tt.path, tt.format); err != nil {
+			t.Fatalf("WriteDocFile(%q): %v", tt.path, err)
+		}
+		data, err := os.ReadFile(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(data), tt.prefix) {
+			t.Errorf("%s starts with %.20q, want %q", tt.path, data, tt.prefix)
+		}
+		want, _ := Marshal(doc, FormatOf(tt.path))
+		if tt.format != "" {
+			want, _ = Marshal(doc, tt.format)
+		}
+		if string(data) != string(want) {
+			t.Errorf("%s differs from Marshal", tt.path)
+		}
+	}
+	if err := WriteDocFile(doc, filepath.Join(dir, "openapi.json"), "toml"); err == nil {
+		t.Error("unsupported format: want an error")
+	}
+}
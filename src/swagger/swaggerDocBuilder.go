@@ -1,26 +1,58 @@
 package swagger
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/ruiborda/go-swagger-generator/src/doccomments"
 	openapi2 "github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/openapi3_spec"
 	entity2 "github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/validation"
 	"reflect"
 	"strings"
 	"sync"
 )
 
-var swaggerDoc openapi2.SwaggerDoc
+var swaggerDoc *SwaggerDocBuilder
 
 type SwaggerDocBuilder struct {
 	doc            *entity2.SwaggerDocEntity
 	definitionsMux sync.Mutex
+
+	errorsMux sync.Mutex
+	errors    []error
+	strict    bool
+
+	comments          doccomments.Comments
+	definitionSources map[string]definitionSource
+	handlers          map[*entity2.OperationEntity]string
+	routePaths        map[string]string // paths relative to the BasePath set when they were registered
+
+	collisions    openapi2.CollisionStrategy
+	namer         func(t reflect.Type) string
+	embeddedAllOf bool
+
+	discriminators   map[reflect.Type]string
+	implementationOf map[reflect.Type]reflect.Type
+	enums            map[reflect.Type][]openapi2.EnumValue
+	typeNames        map[reflect.Type]string
+	definitionTypes  map[string]reflect.Type
+	identifierNames  map[string]string // definition names by qualified type name
 }
 
-func Swagger() openapi2.SwaggerDoc {
-	if swaggerDoc != nil {
-		return swaggerDoc
+// Swagger returns the default document shared by the whole program.
+func Swagger() *SwaggerDocBuilder {
+	if swaggerDoc == nil {
+		swaggerDoc = New()
 	}
-	swaggerDoc = &SwaggerDocBuilder{
+	return swaggerDoc
+}
+
+// New returns an empty document that shares no state with Swagger() or with
+// other documents, e.g. to publish separate public and admin APIs.
+func New() *SwaggerDocBuilder {
+	return &SwaggerDocBuilder{
 		doc: &entity2.SwaggerDocEntity{
 			Swagger:             "2.0",
 			Info:                entity2.InfoEntity{},
@@ -31,8 +63,8 @@ func Swagger() openapi2.SwaggerDoc {
 			Servers:             make([]entity2.ServerEntity, 0),
 			SecurityDefinitions: make(map[string]entity2.SecuritySchemeEntity),
 		},
+		strict: strictFromEnv(),
 	}
-	return swaggerDoc
 }
 
 func (b *SwaggerDocBuilder) SwaggerVersion(version string) openapi2.SwaggerDoc {
@@ -87,7 +119,18 @@ func (b *SwaggerDocBuilder) Schemes(schemes ...string) openapi2.SwaggerDoc {
 	return b
 }
 
+// Path starts the documentation of a path. Both Swagger templates
+// ("/pet/{petId}") and gin routes ("/v2/pet/:petId") are accepted. Paths that
+// start with the BasePath set before Path is called are made relative to it
+// when the document is built; other paths are kept as written.
 func (b *SwaggerDocBuilder) Path(pathPattern string) openapi2.PathItem {
+	pathPattern = FromGinPath(pathPattern)
+	if relative := StripBasePath(pathPattern, b.doc.BasePath); relative != pathPattern {
+		if b.routePaths == nil {
+			b.routePaths = make(map[string]string)
+		}
+		b.routePaths[pathPattern] = relative
+	}
 	pathItem, exists := b.doc.Paths[pathPattern]
 	if !exists {
 		pathItem = entity2.PathItemEntity{}
@@ -96,6 +139,25 @@ func (b *SwaggerDocBuilder) Path(pathPattern string) openapi2.PathItem {
 		pathItem:   &pathItem,
 		docPath:    pathPattern,
 		docBuilder: b,
+		paths:      b.doc.Paths,
+	}
+}
+
+// Webhook documents an out-of-band request the API sends to its consumers.
+// Webhooks only exist in OpenAPI 3.1 and are omitted from other versions.
+func (b *SwaggerDocBuilder) Webhook(name string) openapi2.PathItem {
+	if b.doc.Webhooks == nil {
+		b.doc.Webhooks = make(map[string]entity2.PathItemEntity)
+	}
+	pathItem, exists := b.doc.Webhooks[name]
+	if !exists {
+		pathItem = entity2.PathItemEntity{}
+	}
+	return &PathItemBuilder{
+		pathItem:   &pathItem,
+		docPath:    name,
+		docBuilder: b,
+		paths:      b.doc.Webhooks,
 	}
 }
 
@@ -128,6 +190,9 @@ func (b *SwaggerDocBuilder) DefinitionFromDTO(dtoInstance interface{}) (string,
 	}
 
 	dtoType := reflect.TypeOf(dtoInstance)
+	if dtoType == nil {
+		return "", fmt.Errorf("DTO must not be nil")
+	}
 	if dtoType.Kind() == reflect.Ptr {
 		dtoType = dtoType.Elem()
 	}
@@ -135,7 +200,7 @@ func (b *SwaggerDocBuilder) DefinitionFromDTO(dtoInstance interface{}) (string,
 		return "", fmt.Errorf("DTO must be a struct or pointer to struct, got %s", dtoType.Kind())
 	}
 
-	dtoName := dtoType.Name()
+	dtoName := b.definitionNameFor(dtoType)
 	if _, exists := b.doc.Definitions[dtoName]; !exists {
 		_, err := b.GenerateSchemaFromGoType(dtoType, make(map[string]bool))
 		if err != nil {
@@ -151,7 +216,85 @@ func (b *SwaggerDocBuilder) ExternalDocumentation(url string, description string
 }
 
 func (b *SwaggerDocBuilder) Build() entity2.SwaggerDocEntity {
-	return *b.doc
+	doc := *b.doc
+	doc.Paths = relativePaths(b.describedPaths(b.doc.Paths), b.routePaths)
+	doc.Definitions = b.describedDefinitions(b.doc.Definitions)
+	if renames := b.resolvedNames(); len(renames) > 0 {
+		doc = renameDefinitions(doc, renames)
+	}
+	return doc
+}
+
+// Validate reports the problems of the built document: broken path
+// parameters, duplicate operation ids, unresolved references, operations
+// without responses, mixed body and form parameters and undefined security
+// schemes or scopes. Swagger 2.0 documents are also checked against the
+// official meta-schema. Errors collected by the builders come first.
+func (b *SwaggerDocBuilder) Validate() validation.Violations {
+	var violations validation.Violations
+	for _, err := range b.Errors() {
+		var builderErr *BuilderError
+		if errors.As(err, &builderErr) {
+			violations = append(violations, validation.Violation{Location: builderErr.Location, Message: builderErr.Err.Error()})
+		} else {
+			violations = append(violations, validation.Violation{Message: err.Error()})
+		}
+	}
+	doc := b.Build()
+	structural := validation.ValidateDocument(doc)
+	violations = append(violations, structural...)
+	if strings.HasPrefix(doc.Swagger, "3.") {
+		return violations
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return append(violations, validation.Violation{Message: "document could not be encoded: " + err.Error()})
+	}
+	// The structural checks explain the same mistakes better, e.g. an
+	// operation without responses, so keep one violation per location
+	reported := make(map[string]bool, len(structural))
+	for _, violation := range structural {
+		reported[violation.Location] = true
+	}
+	for _, violation := range validation.ValidateSwagger2Schema(data) {
+		if !reported[violation.Location] {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// BuildOpenAPI3 converts the document into its OpenAPI 3.0 form.
+func (b *SwaggerDocBuilder) BuildOpenAPI3() openapi3_spec.OpenAPIEntity {
+	return openapi3_spec.FromSwagger2(b.Build())
+}
+
+// BuildOpenAPI31 converts the document into its OpenAPI 3.1 form.
+func (b *SwaggerDocBuilder) BuildOpenAPI31() openapi3_spec.OpenAPIEntity {
+	return openapi3_spec.FromSwagger2V31(b.Build())
+}
+
+// BuildSpec returns the document in the version selected with SwaggerVersion:
+// an OpenAPI 3.1 or 3.0 document for "3.1.x" and "3.0.x" versions and the
+// Swagger 2.0 entity otherwise.
+func (b *SwaggerDocBuilder) BuildSpec() interface{} {
+	switch {
+	case strings.HasPrefix(b.doc.Swagger, "3.1"):
+		return b.BuildOpenAPI31()
+	case strings.HasPrefix(b.doc.Swagger, "3."):
+		return b.BuildOpenAPI3()
+	}
+	return b.Build()
+}
+
+// BuildSpec returns doc in the version selected with SwaggerVersion, see
+// SwaggerDocBuilder.BuildSpec. Other implementations of openapi.SwaggerDoc
+// are built as Swagger 2.0.
+func BuildSpec(doc openapi2.SwaggerDoc) interface{} {
+	if builder, ok := doc.(interface{ BuildSpec() interface{} }); ok {
+		return builder.BuildSpec()
+	}
+	return doc.Build()
 }
 
 func (b *SwaggerDocBuilder) GenerateSchemaFromGoType(t reflect.Type, visited map[string]bool) (*entity2.SchemaEntity, error) {
@@ -164,12 +307,12 @@ func (b *SwaggerDocBuilder) GenerateSchemaFromGoType(t reflect.Type, visited map
 		typeName := t.PkgPath() + "." + t.Name() // Unique identifier for the type
 		if visited[typeName] {
 			// If this type name is already in Definitions, it's a known DTO
-			if _, exists := b.doc.Definitions[t.Name()]; exists {
-				return &entity2.SchemaEntity{Ref: "#/definitions/" + t.Name()}, nil
+			if _, exists := b.doc.Definitions[b.definitionNameFor(t)]; exists {
+				return &entity2.SchemaEntity{Ref: "#/definitions/" + b.definitionNameFor(t)}, nil
 			}
 			// Otherwise, it's a recursive call within the same DTO generation, return a temporary ref
 			// This case might need more robust handling if complex anonymous struct recursions are expected
-			return &entity2.SchemaEntity{Ref: "#/definitions/" + t.Name()}, nil // Hope t.Name() is unique enough
+			return &entity2.SchemaEntity{Ref: "#/definitions/" + b.definitionNameFor(t)}, nil // Hope the name is unique enough
 		}
 		visited[typeName] = true
 		defer delete(visited, typeName) // Clean up after processing this type
@@ -204,8 +347,8 @@ func (b *SwaggerDocBuilder) GenerateSchemaFromGoType(t reflect.Type, visited map
 			elemType = elemType.Elem()
 		}
 		// Simple self-reference check for arrays/slices of the DTO itself
-		if elemType.Name() == t.Name() && elemType.PkgPath() == t.PkgPath() {
-			schema.Items = &entity2.SchemaEntity{Ref: "#/definitions/" + elemType.Name()}
+		if elemType.Kind() == reflect.Struct && elemType.Name() != "" && elemType.Name() == t.Name() && elemType.PkgPath() == t.PkgPath() {
+			schema.Items = &entity2.SchemaEntity{Ref: "#/definitions/" + b.definitionNameFor(elemType)}
 		} else {
 			itemSchema, err := b.GenerateSchemaFromGoType(elemType, visited)
 			if err != nil {
@@ -220,60 +363,48 @@ func (b *SwaggerDocBuilder) GenerateSchemaFromGoType(t reflect.Type, visited map
 			schema.Format = "date-time"
 			return schema, nil
 		}
+		if embedded, marshaler, ok := promotedMarshaler(t); ok {
+			if marshaler == textMarshalerType { // encoded as a JSON string
+				schema.Type = "string"
+				break
+			}
+			embeddedSchema, err := b.GenerateSchemaFromGoType(embedded.Type, visited)
+			if err != nil {
+				return nil, err
+			}
+			schema = embeddedSchema
+			break
+		}
 
-		// This struct will be a definition
-		dtoName := t.Name()
+		// This struct will be a definition, anonymous structs have no name to
+		// be referenced by and are described inline
+		dtoName := b.definitionNameFor(t)
+		if dtoName == "" {
+			structSchema, _, err := b.structSchema(t, visited)
+			if err != nil {
+				return nil, err
+			}
+			schema = &structSchema
+			break
+		}
 		schema.Ref = "#/definitions/" + dtoName
 
-		// If this definition doesn't exist yet, create it
+		// If this definition doesn't exist yet, create it. The enum of the
+		// type belongs to the definition, Swagger 2.0 ignores the siblings
+		// of $ref.
 		if _, exists := b.doc.Definitions[dtoName]; !exists {
-			// Temporarily add to definitions to handle self-references within fields
-			// b.doc.Definitions[dtoName] = SchemaEntity{Ref: "#/definitions/" + dtoName} // Placeholder for recursion
-
-			fullStructSchema := entity2.SchemaEntity{
-				Type:       "object",
-				Properties: make(map[string]*entity2.SchemaEntity),
-				Required:   []string{},
-			}
-			for i := 0; i < t.NumField(); i++ {
-				field := t.Field(i)
-				if !field.IsExported() { // Skip unexported fields
-					continue
-				}
-
-				jsonTag := field.Tag.Get("json")
-				jsonFieldName := field.Name // Default to field name
-				omitempty := false
-				if jsonTag != "" {
-					parts := strings.Split(jsonTag, ",")
-					if parts[0] == "-" { // Field is ignored
-						continue
-					}
-					if parts[0] != "" {
-						jsonFieldName = parts[0]
-					}
-					for _, part := range parts[1:] {
-						if part == "omitempty" {
-							omitempty = true
-							break
-						}
-					}
-				}
-
-				propSchema, err := b.GenerateSchemaFromGoType(field.Type, visited)
-				if err != nil {
-					return nil, fmt.Errorf("failed to generate schema for field %s in struct %s: %w", field.Name, dtoName, err)
-				}
-				fullStructSchema.Properties[jsonFieldName] = propSchema
-				if !omitempty { // Add to required if not omitempty
-					fullStructSchema.Required = append(fullStructSchema.Required, jsonFieldName)
-				}
+			fullStructSchema, fieldNames, err := b.structSchema(t, visited)
+			if err != nil {
+				return nil, err
 			}
-			if len(fullStructSchema.Required) == 0 {
-				fullStructSchema.Required = nil // omit if empty
+			definition := b.polymorphicSchema(t, fullStructSchema, fieldNames)
+			if values, ok := b.enumValues(t); ok {
+				applyEnum(&definition, values)
 			}
-			b.doc.Definitions[dtoName] = fullStructSchema // Replace placeholder with full schema
+			b.doc.Definitions[dtoName] = definition
+			b.recordDefinition(dtoName, t, fieldNames)
 		}
+		return schema, nil
 	case reflect.Map:
 		schema.Type = "object"
 		// Swagger 2.0 only supports string keys for maps.
@@ -285,9 +416,95 @@ func (b *SwaggerDocBuilder) GenerateSchemaFromGoType(t reflect.Type, visited map
 		}
 		schema.AdditionalProperties = addPropsSchema
 
+	case reflect.Interface:
+		return b.interfaceSchema(t)
+
 	default:
 		return nil, fmt.Errorf("unsupported type for DTO schema generation: %s", t.Kind())
 	}
 
+	if values, ok := b.enumValues(t); ok {
+		if schema.Ref != "" { // e.g. a struct promoting MarshalJSON
+			schema = &entity2.SchemaEntity{AllOf: []*entity2.SchemaEntity{schema}}
+		}
+		applyEnum(schema, values)
+	}
+
 	return schema, nil
 }
+
+// structSchema describes the fields encoding/json encodes for a struct as an
+// object schema and returns where each property is declared. Fields of
+// embedded structs are promoted, or referenced through allOf with
+// EmbeddedAllOf.
+func (b *SwaggerDocBuilder) structSchema(t reflect.Type, visited map[string]bool) (entity2.SchemaEntity, map[string]fieldSource, error) {
+	fullStructSchema := entity2.SchemaEntity{
+		Type:       "object",
+		Properties: make(map[string]*entity2.SchemaEntity),
+		Required:   []string{},
+	}
+	fieldSources := make(map[string]fieldSource)
+	fields := jsonFields(t)
+
+	var allOf []*entity2.SchemaEntity
+	if b.embeddedAllOf {
+		for _, embedded := range embeddedStructs(t) {
+			embeddedSchema, err := b.GenerateSchemaFromGoType(embedded.Type, visited)
+			if err != nil {
+				return entity2.SchemaEntity{}, nil, fmt.Errorf("failed to generate schema for embedded %s in struct %s: %w", embedded.Name, b.definitionNameFor(t), err)
+			}
+			if embeddedSchema.Ref != "" { // e.g. not time.Time
+				allOf = append(allOf, embeddedSchema)
+			}
+		}
+		if len(allOf) > 0 { // keep the struct's own fields
+			own := fields[:0:0]
+			for _, f := range fields {
+				if len(f.index) == 1 {
+					own = append(own, f)
+				}
+			}
+			fields = own
+		}
+	}
+
+	for _, f := range fields {
+		field := f.field
+		propSchema, err := b.GenerateSchemaFromGoType(field.Type, visited)
+		if err != nil {
+			return entity2.SchemaEntity{}, nil, fmt.Errorf("failed to generate schema for field %s in struct %s: %w", field.Name, b.definitionNameFor(t), err)
+		}
+		rules := bindingRules(field)
+		tagErrs := applyBindingRules(propSchema, field.Type, rules)
+		propSchema, docErrs := docTaggedSchema(propSchema, field)
+		for _, err := range append(tagErrs, docErrs...) {
+			b.reportError(fmt.Sprintf("definitions.%s field %s", b.definitionNameFor(t), field.Name), err)
+		}
+		if field.Type.Kind() == reflect.Ptr { // rendered as nullable in OpenAPI 3
+			if propSchema.Ref != "" {
+				if fullStructSchema.NullableProperties == nil {
+					fullStructSchema.NullableProperties = make(map[string]bool)
+				}
+				fullStructSchema.NullableProperties[f.name] = true
+			} else {
+				propSchema.Nullable = true
+			}
+		}
+		fullStructSchema.Properties[f.name] = propSchema
+		fieldSources[f.name] = fieldSource{owner: f.owner, name: field.Name}
+		omitempty := f.omitempty
+		if required, decided := bindingRequired(rules); decided {
+			omitempty = !required
+		}
+		if !omitempty && !f.viaPointer { // Add to required if not omitempty
+			fullStructSchema.Required = append(fullStructSchema.Required, f.name)
+		}
+	}
+	if len(fullStructSchema.Required) == 0 {
+		fullStructSchema.Required = nil // omit if empty
+	}
+	if len(allOf) > 0 {
+		return entity2.SchemaEntity{AllOf: append(allOf, &fullStructSchema)}, fieldSources, nil
+	}
+	return fullStructSchema, fieldSources, nil
+}
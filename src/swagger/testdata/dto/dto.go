@@ -0,0 +1,12 @@
+// Package dto holds DTOs sharing their names with the ones of package other,
+// for the definition name tests.
+package dto
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type User struct {
+	Name string `json:"name"`
+	Tags []Tag  `json:"tags"`
+}
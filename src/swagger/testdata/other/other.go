@@ -0,0 +1,11 @@
+// Package other holds DTOs sharing their names with the ones of package dto,
+// for the definition name tests.
+package other
+
+type Tag struct {
+	Label string `json:"label"`
+}
+
+type User struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,7 @@
+// Package model shares its name with the model package of another version,
+// for the definition name tests.
+package model
+
+type Item struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,121 @@
+// Command fetch downloads the files of the swagger-ui-dist npm package that
+// the swaggerui package embeds. It is run by go generate.
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// files are the parts of the package needed to render the documentation page.
+var files = []string{
+	"swagger-ui.css",
+	"swagger-ui-bundle.js",
+	"swagger-ui-standalone-preset.js",
+	"oauth2-redirect.html",
+	"favicon-16x16.png",
+	"favicon-32x32.png",
+	"LICENSE",
+	"NOTICE",
+}
+
+// required are the files the page cannot work without and the license
+// files that must be distributed with them.
+var required = map[string]bool{
+	"swagger-ui.css":                  true,
+	"swagger-ui-bundle.js":            true,
+	"swagger-ui-standalone-preset.js": true,
+	"LICENSE":                         true,
+	"NOTICE":                          true,
+}
+
+func main() {
+	version := flag.String("version", "", "swagger-ui-dist version")
+	out := flag.String("out", "dist", "output directory")
+	registry := flag.String("registry", "https://registry.npmjs.org", "npm registry, e.g. an internal mirror")
+	flag.Parse()
+	if *version == "" {
+		fmt.Fprintln(os.Stderr, "fetch: -version is required")
+		os.Exit(2)
+	}
+	if err := fetch(*registry, *version, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "fetch:", err)
+		os.Exit(1)
+	}
+}
+
+func fetch(registry, version, out string) error {
+	url := fmt.Sprintf("%s/swagger-ui-dist/-/swagger-ui-dist-%s.tgz", strings.TrimSuffix(registry, "/"), version)
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]bool, len(files))
+	for _, name := range files {
+		wanted[name] = true
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// npm tarballs keep their files under "package/".
+		name := path.Base(header.Name)
+		if header.Typeflag != tar.TypeReg || path.Dir(header.Name) != "package" || !wanted[name] {
+			continue
+		}
+		if err := writeFile(filepath.Join(out, name), archive); err != nil {
+			return err
+		}
+		delete(wanted, name)
+	}
+	for _, name := range files {
+		if !wanted[name] {
+			continue
+		}
+		if required[name] {
+			return fmt.Errorf("%s not found in swagger-ui-dist %s", name, version)
+		}
+		fmt.Fprintf(os.Stderr, "fetch: %s not found in swagger-ui-dist %s\n", name, version)
+	}
+	return nil
+}
+
+func writeFile(name string, r io.Reader) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
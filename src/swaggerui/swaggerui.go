@@ -0,0 +1,52 @@
+// Package swaggerui embeds the Swagger UI distribution (swagger-ui-dist) so
+// the documentation page works without access to a CDN.
+//
+// The files in dist are fetched from the npm registry with go generate and
+// committed, so users of the module need no network access:
+//
+//	go generate ./src/swaggerui
+package swaggerui
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:generate go run ./internal/fetch -version 5.11.0 -out dist
+
+// Version is the swagger-ui-dist version fetched into dist.
+const Version = "5.11.0"
+
+// CDNURL is the public CDN serving the same version, used when the
+// embedded files are missing.
+const CDNURL = "https://unpkg.com/swagger-ui-dist@" + Version
+
+// Files served by the documentation page.
+const (
+	CSS              = "swagger-ui.css"
+	Bundle           = "swagger-ui-bundle.js"
+	StandalonePreset = "swagger-ui-standalone-preset.js"
+)
+
+//go:embed dist
+var dist embed.FS
+
+// Assets returns the embedded swagger-ui-dist files.
+func Assets() fs.FS {
+	assets, err := fs.Sub(dist, "dist")
+	if err != nil {
+		panic(err) // dist is always embedded
+	}
+	return assets
+}
+
+// Available reports whether the Swagger UI files were embedded. It is false
+// in a checkout where go generate has not been run yet.
+func Available() bool {
+	for _, name := range []string{CSS, Bundle, StandalonePreset} {
+		if _, err := fs.Stat(dist, "dist/"+name); err != nil {
+			return false
+		}
+	}
+	return true
+}
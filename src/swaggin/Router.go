@@ -0,0 +1,127 @@
+package swaggin
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+)
+
+// Router registers gin routes together with their documentation, so the
+// path and method of a route and of its operation cannot drift apart.
+// Swagger 2.0 paths are relative to the document's BasePath, so routes must
+// be registered under it; reconcile.Gin reports the ones that are not.
+type Router struct {
+	router gin.IRouter
+	doc    openapi.SwaggerDoc
+}
+
+// Wrap documents the routes of router (a *gin.Engine or *gin.RouterGroup)
+// in the default swagger.Swagger() document.
+func Wrap(router gin.IRouter) *Router {
+	return WrapDoc(router, swagger.Swagger())
+}
+
+// WrapDoc documents the routes of router in doc.
+func WrapDoc(router gin.IRouter, doc openapi.SwaggerDoc) *Router {
+	return &Router{router: router, doc: doc}
+}
+
+// Unwrap returns the underlying gin router.
+func (r *Router) Unwrap() gin.IRouter {
+	return r.router
+}
+
+// Doc returns the document the routes are written to.
+func (r *Router) Doc() openapi.SwaggerDoc {
+	return r.doc
+}
+
+// Group creates a route group whose routes are documented with the group prefix.
+func (r *Router) Group(relativePath string, handlers ...gin.HandlerFunc) *Router {
+	return &Router{router: r.router.Group(relativePath, handlers...), doc: r.doc}
+}
+
+// Use adds middleware to the underlying router.
+func (r *Router) Use(middleware ...gin.HandlerFunc) *Router {
+	r.router.Use(middleware...)
+	return r
+}
+
+// Handle registers a route and documents it with config. A nil config
+// registers the route without documenting it. The last handler is recorded
+// as the operation's Handler.
+func (r *Router) Handle(method, relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	routes := r.router.Handle(method, relativePath, handlers...)
+	if config == nil {
+		return routes
+	}
+
+	if len(handlers) > 0 {
+		documented := config
+		handler := handlers[len(handlers)-1]
+		config = func(op openapi.Operation) {
+			op.Handler(handler)
+			documented(op)
+		}
+	}
+	pathItem := r.doc.Path(r.fullPath(relativePath))
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		pathItem.Get(config)
+	case http.MethodPost:
+		pathItem.Post(config)
+	case http.MethodPut:
+		pathItem.Put(config)
+	case http.MethodDelete:
+		pathItem.Delete(config)
+	case http.MethodPatch:
+		pathItem.Patch(config)
+	case http.MethodOptions:
+		pathItem.Options(config)
+	case http.MethodHead:
+		pathItem.Head(config)
+	}
+	return routes
+}
+
+func (r *Router) GET(relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodGet, relativePath, config, handlers...)
+}
+func (r *Router) POST(relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodPost, relativePath, config, handlers...)
+}
+func (r *Router) PUT(relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodPut, relativePath, config, handlers...)
+}
+func (r *Router) DELETE(relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodDelete, relativePath, config, handlers...)
+}
+func (r *Router) PATCH(relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodPatch, relativePath, config, handlers...)
+}
+func (r *Router) OPTIONS(relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodOptions, relativePath, config, handlers...)
+}
+func (r *Router) HEAD(relativePath string, config func(openapi.Operation), handlers ...gin.HandlerFunc) gin.IRoutes {
+	return r.Handle(http.MethodHead, relativePath, config, handlers...)
+}
+
+// fullPath joins the group prefix and relativePath the same way gin does.
+func (r *Router) fullPath(relativePath string) string {
+	basePath := "/"
+	if group, ok := r.router.(interface{ BasePath() string }); ok {
+		basePath = group.BasePath()
+	}
+	if relativePath == "" {
+		return basePath
+	}
+	full := path.Join(basePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(full, "/") {
+		full += "/"
+	}
+	return full
+}
@@ -0,0 +1,199 @@
+package swaggin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruiborda/go-swagger-generator/src/openapi"
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+	"github.com/ruiborda/go-swagger-generator/src/reconcile"
+	"github.com/ruiborda/go-swagger-generator/src/swagger"
+)
+
+func documented(op openapi.Operation) {
+	op.Response(http.StatusOK, func(r openapi.Response) { r.Description("OK") })
+}
+
+func ok(c *gin.Context) { c.Status(http.StatusOK) }
+
+// operations lists the documented operations of doc as "METHOD path".
+func operations(doc openapi_spec.SwaggerDocEntity) []string {
+	var result []string
+	for path, item := range doc.Paths {
+		for method, op := range map[string]*openapi_spec.OperationEntity{
+			http.MethodGet: item.Get, http.MethodPost: item.Post, http.MethodPut: item.Put,
+			http.MethodDelete: item.Delete, http.MethodPatch: item.Patch,
+			http.MethodOptions: item.Options, http.MethodHead: item.Head,
+		} {
+			if op != nil {
+				result = append(result, method+" "+path)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestGroupPrefixes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		basePath string
+		register func(r *Router)
+		want     []string
+	}{
+		{
+			name: "engine",
+			register: func(r *Router) {
+				r.GET("/pets", documented, ok)
+				r.POST("pets", documented, ok)
+				r.GET("/", documented, ok)
+			},
+			want: []string{"GET /", "GET /pets", "POST /pets"},
+		},
+		{
+			name: "group",
+			register: func(r *Router) {
+				api := r.Group("/api")
+				api.GET("/pets", documented, ok)
+				api.GET("", documented, ok)
+			},
+			want: []string{"GET /api", "GET /api/pets"},
+		},
+		{
+			name: "nested groups with parameters",
+			register: func(r *Router) {
+				pet := r.Group("/api").Group("/pets/:petId")
+				pet.GET("", documented, ok)
+				pet.DELETE("/", documented, ok)
+				pet.PUT("/photos/:photoId", documented, ok)
+				pet.GET("/files/*path", documented, ok)
+			},
+			want: []string{
+				"DELETE /api/pets/{petId}/",
+				"GET /api/pets/{petId}",
+				"GET /api/pets/{petId}/files/{path}",
+				"PUT /api/pets/{petId}/photos/{photoId}",
+			},
+		},
+		{
+			name: "slashes",
+			register: func(r *Router) {
+				api := r.Group("/api/")
+				api.GET("pets", documented, ok)
+				api.GET("//owners/", documented, ok)
+				api.Group("store").PATCH("orders", documented, ok)
+			},
+			want: []string{"GET /api/owners/", "GET /api/pets", "PATCH /api/store/orders"},
+		},
+		{
+			name:     "base path",
+			basePath: "/api",
+			register: func(r *Router) {
+				api := r.Group("/api")
+				api.GET("/pets/:id", documented, ok)
+				api.Group("/api").POST("/keys", documented, ok)
+			},
+			want: []string{"GET /pets/{id}", "POST /api/keys"},
+		},
+		{
+			name: "every method",
+			register: func(r *Router) {
+				pets := r.Group("/pets")
+				pets.GET("", documented, ok)
+				pets.POST("", documented, ok)
+				pets.PUT("", documented, ok)
+				pets.DELETE("", documented, ok)
+				pets.PATCH("", documented, ok)
+				pets.OPTIONS("", documented, ok)
+				pets.HEAD("", documented, ok)
+			},
+			want: []string{"DELETE /pets", "GET /pets", "HEAD /pets", "OPTIONS /pets", "PATCH /pets", "POST /pets", "PUT /pets"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := swagger.New()
+			doc.BasePath(tt.basePath)
+			engine := gin.New()
+			tt.register(WrapDoc(engine, doc))
+
+			built := doc.Build()
+			if got := operations(built); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("operations %q, want %q", got, tt.want)
+			}
+			if err := reconcile.Gin(engine.Routes(), built).Err(); err != nil {
+				t.Error(err)
+			}
+			if errs := doc.Errors(); len(errs) > 0 {
+				t.Errorf("unexpected errors %v", errs)
+			}
+		})
+	}
+}
+
+func TestUndocumentedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	engine := gin.New()
+	api := WrapDoc(engine, doc).Group("/api")
+	api.GET("/pets", documented, ok)
+	api.GET("/health", nil, ok)
+
+	report := reconcile.Gin(engine.Routes(), doc.Build())
+	want := []reconcile.Route{{Method: "GET", Path: "/api/health"}}
+	if !reflect.DeepEqual(report.Undocumented, want) || len(report.Unimplemented) > 0 {
+		t.Errorf("report\n%s\nwant only GET /api/health undocumented", report)
+	}
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /api/health: %d, want the route to be registered", rec.Code)
+	}
+}
+
+func TestGroupMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	engine := gin.New()
+	var calls []string
+	trace := func(name string) gin.HandlerFunc {
+		return func(c *gin.Context) { calls = append(calls, name) }
+	}
+	api := WrapDoc(engine, doc).Use(trace("engine")).Group("/api", trace("group"))
+	api.GET("/pets", documented, trace("handler"))
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/pets", nil))
+	if want := []string{"engine", "group", "handler"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls %q, want %q", calls, want)
+	}
+	if api.Doc() != openapi.SwaggerDoc(doc) {
+		t.Error("groups must write to the wrapped document")
+	}
+	if _, isGroup := api.Unwrap().(*gin.RouterGroup); !isGroup {
+		t.Errorf("Unwrap() = %T, want the gin group", api.Unwrap())
+	}
+}
+
+func TestRouteOutsideBasePath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := swagger.New()
+	doc.BasePath("/api")
+	engine := gin.New()
+	WrapDoc(engine, doc).HEAD("/health", documented, ok)
+
+	// documented as /health relative to the BasePath, i.e. /api/health
+	report := reconcile.Gin(engine.Routes(), doc.Build())
+	want := reconcile.Report{
+		Undocumented:  []reconcile.Route{{Method: "HEAD", Path: "/health"}},
+		Unimplemented: []reconcile.Route{{Method: "HEAD", Path: "/health"}},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report\n%s\nwant\n%s", report, want)
+	}
+}
@@ -0,0 +1,197 @@
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// ValidateDocument checks the rules of the Swagger 2.0 specification that a
+// JSON schema cannot express: path template parameters, unique operation
+// ids, resolvable references, responses, body and form parameters and
+// security requirements.
+func ValidateDocument(doc openapi_spec.SwaggerDocEntity) Violations {
+	var violations Violations
+	operationIDs := make(map[string]string)
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		itemLocation := "paths." + path
+		for i, param := range item.Parameters {
+			validateParameterRefs(doc, fmt.Sprintf("%s.parameters[%d]", itemLocation, i), param, &violations)
+		}
+		for _, method := range documentMethods {
+			op := operationFor(item, method)
+			if op == nil {
+				continue
+			}
+			location := itemLocation + "." + strings.ToLower(method)
+
+			if op.OperationID != "" {
+				if first, ok := operationIDs[op.OperationID]; ok {
+					violations.add(location+".operationId", "%q is already used by %s", op.OperationID, first)
+				} else {
+					operationIDs[op.OperationID] = location
+				}
+			}
+			if len(op.Responses) == 0 {
+				violations.add(location+".responses", "must declare at least one response")
+			}
+
+			params := mergeParameters(item.Parameters, op.Parameters)
+			validatePathParameters(path, location, params, &violations)
+			validateParameterKinds(location, op.Parameters, params, &violations)
+			for i, param := range op.Parameters {
+				validateParameterRefs(doc, fmt.Sprintf("%s.parameters[%d]", location, i), param, &violations)
+			}
+			for _, code := range sortedKeys(op.Responses) {
+				response := op.Responses[code]
+				validateSchemaRefs(doc, location+".responses."+code+".schema", response.Schema, &violations)
+			}
+			validateSecurity(doc, location+".security", op.Security, &violations)
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		definition := doc.Definitions[name]
+		validateSchemaRefs(doc, "definitions."+name, &definition, &violations)
+	}
+	return violations
+}
+
+var documentMethods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch,
+}
+
+// validatePathParameters checks that every "{param}" of the template has an
+// "in: path" parameter and that every path parameter appears in the template.
+func validatePathParameters(path, location string, params []openapi_spec.ParameterEntity, violations *Violations) {
+	inTemplate := make(map[string]bool)
+	for _, segment := range strings.Split(path, "/") {
+		if len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			inTemplate[segment[1:len(segment)-1]] = true
+		}
+	}
+	declared := make(map[string]bool)
+	for _, param := range params {
+		if param.In != "path" {
+			continue
+		}
+		declared[param.Name] = true
+		if !inTemplate[param.Name] {
+			violations.add(location+".parameters", "path parameter %q does not appear in the path template", param.Name)
+		}
+		if !param.Required {
+			violations.add(location+".parameters", "path parameter %q must be required", param.Name)
+		}
+	}
+	for _, name := range sortedKeys(inTemplate) {
+		if !declared[name] {
+			violations.add(location+".parameters", "path template parameter {%s} has no matching path parameter", name)
+		}
+	}
+}
+
+// validateParameterKinds checks that an operation has at most one body
+// parameter, never mixes body and formData parameters and does not repeat a
+// parameter.
+func validateParameterKinds(location string, own, params []openapi_spec.ParameterEntity, violations *Violations) {
+	bodies, forms := 0, 0
+	for _, param := range params {
+		switch param.In {
+		case "body":
+			bodies++
+		case "formData":
+			forms++
+		}
+	}
+	if bodies > 1 {
+		violations.add(location+".parameters", "must not have more than one body parameter")
+	}
+	if bodies > 0 && forms > 0 {
+		violations.add(location+".parameters", "must not have both body and formData parameters")
+	}
+	seen := make(map[string]bool)
+	for _, param := range own {
+		key := param.In + "." + param.Name
+		if seen[key] {
+			violations.add(location+".parameters", "parameter %q in %s is declared more than once", param.Name, param.In)
+		}
+		seen[key] = true
+	}
+}
+
+func validateParameterRefs(doc openapi_spec.SwaggerDocEntity, location string, param openapi_spec.ParameterEntity, violations *Violations) {
+	validateSchemaRefs(doc, location+".schema", param.Schema, violations)
+	validateSchemaRefs(doc, location+".items", param.Items, violations)
+}
+
+// validateSchemaRefs checks that every reference inside schema points to an
+// entry of doc.Definitions.
+func validateSchemaRefs(doc openapi_spec.SwaggerDocEntity, location string, schema *openapi_spec.SchemaEntity, violations *Violations) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if !strings.HasPrefix(schema.Ref, definitionsRefPrefix) {
+			violations.add(location, "reference %s must point to %s...", schema.Ref, definitionsRefPrefix)
+		} else if _, ok := doc.Definitions[strings.TrimPrefix(schema.Ref, definitionsRefPrefix)]; !ok {
+			violations.add(location, "reference %s does not resolve to a definition", schema.Ref)
+		}
+	}
+	validateSchemaRefs(doc, location+".items", schema.Items, violations)
+	for i, sub := range schema.AllOf {
+		validateSchemaRefs(doc, fmt.Sprintf("%s.allOf[%d]", location, i), sub, violations)
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		validateSchemaRefs(doc, location+".properties."+name, schema.Properties[name], violations)
+	}
+	switch additional := schema.AdditionalProperties.(type) {
+	case *openapi_spec.SchemaEntity:
+		validateSchemaRefs(doc, location+".additionalProperties", additional, violations)
+	case openapi_spec.SchemaEntity:
+		validateSchemaRefs(doc, location+".additionalProperties", &additional, violations)
+	}
+}
+
+// validateSecurity checks that requirements name defined schemes and, for
+// OAuth2, defined scopes; other schemes take no scopes.
+func validateSecurity(doc openapi_spec.SwaggerDocEntity, location string, requirements []map[string][]string, violations *Violations) {
+	for i, requirement := range requirements {
+		for _, name := range sortedKeys(requirement) {
+			scheme, ok := doc.SecurityDefinitions[name]
+			if !ok {
+				violations.add(fmt.Sprintf("%s[%d]", location, i), "security scheme %q is not defined", name)
+				continue
+			}
+			for _, scope := range requirement[name] {
+				if scheme.Type != "oauth2" {
+					violations.add(fmt.Sprintf("%s[%d].%s", location, i, name), "%s scheme does not take scopes", scheme.Type)
+					break
+				}
+				if _, ok := scheme.Scopes[scope]; !ok {
+					violations.add(fmt.Sprintf("%s[%d].%s", location, i, name), "scope %q is not defined by the scheme", scope)
+				}
+			}
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+func TestValidateDocument(t *testing.T) {
+	ok := map[string]openapi_spec.ResponseEntity{"200": {Description: "OK"}}
+	ref := func(ref string) *openapi_spec.SchemaEntity { return &openapi_spec.SchemaEntity{Ref: ref} }
+	pet := openapi_spec.SchemaEntity{Type: "object"}
+	tests := []struct {
+		name string
+		doc  openapi_spec.SwaggerDocEntity
+		want Violations
+	}{
+		{
+			name: "valid document",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets/{id}": {Get: &openapi_spec.OperationEntity{
+					Parameters: []openapi_spec.ParameterEntity{{Name: "id", In: "path", Required: true, Type: "string"}},
+					Responses:  map[string]openapi_spec.ResponseEntity{"200": {Description: "OK", Schema: ref("#/definitions/Pet")}},
+				}}},
+				Definitions: map[string]openapi_spec.SchemaEntity{"Pet": pet},
+			},
+		},
+		{
+			name: "unresolved references",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets": {Post: &openapi_spec.OperationEntity{
+					Parameters: []openapi_spec.ParameterEntity{{Name: "pet", In: "body", Schema: ref("#/definitions/NewPet")}},
+					Responses:  map[string]openapi_spec.ResponseEntity{"200": {Description: "OK", Schema: &openapi_spec.SchemaEntity{Type: "array", Items: ref("#/definitions/Pets")}}},
+				}}},
+				Definitions: map[string]openapi_spec.SchemaEntity{
+					"Pet": {
+						AllOf:                []*openapi_spec.SchemaEntity{ref("#/definitions/Animal")},
+						Properties:           map[string]*openapi_spec.SchemaEntity{"owner": ref("#/definitions/Owner")},
+						AdditionalProperties: ref("#/definitions/Tag"),
+					},
+				},
+			},
+			want: Violations{
+				{"paths./pets.post.parameters[0].schema", "reference #/definitions/NewPet does not resolve to a definition"},
+				{"paths./pets.post.responses.200.schema.items", "reference #/definitions/Pets does not resolve to a definition"},
+				{"definitions.Pet.allOf[0]", "reference #/definitions/Animal does not resolve to a definition"},
+				{"definitions.Pet.properties.owner", "reference #/definitions/Owner does not resolve to a definition"},
+				{"definitions.Pet.additionalProperties", "reference #/definitions/Tag does not resolve to a definition"},
+			},
+		},
+		{
+			name: "references outside definitions",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets": {
+					Parameters: []openapi_spec.ParameterEntity{{Name: "ids", In: "query", Type: "array", Items: ref("#/components/schemas/Id")}},
+					Get:        &openapi_spec.OperationEntity{Responses: map[string]openapi_spec.ResponseEntity{"200": {Description: "OK", Schema: ref("pet.json")}}},
+				}},
+			},
+			want: Violations{
+				{"paths./pets.parameters[0].items", "reference #/components/schemas/Id must point to #/definitions/..."},
+				{"paths./pets.get.responses.200.schema", "reference pet.json must point to #/definitions/..."},
+			},
+		},
+		{
+			name: "duplicate parameters",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets": {Get: &openapi_spec.OperationEntity{
+					Parameters: []openapi_spec.ParameterEntity{
+						{Name: "limit", In: "query", Type: "integer"},
+						{Name: "limit", In: "query", Type: "string"},
+						{Name: "limit", In: "header", Type: "string"},
+					},
+					Responses: ok,
+				}}},
+			},
+			want: Violations{{"paths./pets.get.parameters", `parameter "limit" in query is declared more than once`}},
+		},
+		{
+			name: "path level parameter overridden by the operation",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets": {
+					Parameters: []openapi_spec.ParameterEntity{{Name: "limit", In: "query", Type: "integer"}},
+					Get: &openapi_spec.OperationEntity{
+						Parameters: []openapi_spec.ParameterEntity{{Name: "limit", In: "query", Type: "string"}},
+						Responses:  ok,
+					},
+				}},
+			},
+		},
+		{
+			name: "body and form parameters",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets": {Post: &openapi_spec.OperationEntity{
+					Parameters: []openapi_spec.ParameterEntity{
+						{Name: "a", In: "body", Schema: &pet},
+						{Name: "b", In: "body", Schema: &pet},
+						{Name: "name", In: "formData", Type: "string"},
+					},
+					Responses: ok,
+				}}},
+			},
+			want: Violations{
+				{"paths./pets.post.parameters", "must not have more than one body parameter"},
+				{"paths./pets.post.parameters", "must not have both body and formData parameters"},
+			},
+		},
+		{
+			name: "path parameters",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets/{id}": {Get: &openapi_spec.OperationEntity{
+					Parameters: []openapi_spec.ParameterEntity{{Name: "petId", In: "path", Type: "string"}},
+					Responses:  ok,
+				}}},
+			},
+			want: Violations{
+				{"paths./pets/{id}.get.parameters", `path parameter "petId" does not appear in the path template`},
+				{"paths./pets/{id}.get.parameters", `path parameter "petId" must be required`},
+				{"paths./pets/{id}.get.parameters", "path template parameter {id} has no matching path parameter"},
+			},
+		},
+		{
+			name: "operation ids and responses",
+			doc: openapi_spec.SwaggerDocEntity{
+				Paths: map[string]openapi_spec.PathItemEntity{"/pets": {
+					Get:  &openapi_spec.OperationEntity{OperationID: "pets", Responses: ok},
+					Post: &openapi_spec.OperationEntity{OperationID: "pets"},
+				}},
+			},
+			want: Violations{
+				{"paths./pets.post.operationId", `"pets" is already used by paths./pets.get`},
+				{"paths./pets.post.responses", "must declare at least one response"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateDocument(tt.doc); got.Error() != tt.want.Error() {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
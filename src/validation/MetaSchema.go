@@ -0,0 +1,453 @@
+package validation
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// The Swagger 2.0 meta-schema (http://swagger.io/v2/schema.json) and the JSON
+// Schema draft-04 meta-schema it references.
+//
+//go:embed schemas/swagger-2.0.json schemas/draft-04.json
+var metaSchemaFiles embed.FS
+
+const (
+	swagger2SchemaID = "http://swagger.io/v2/schema.json"
+	draft04SchemaID  = "http://json-schema.org/draft-04/schema"
+)
+
+var (
+	metaSchemasOnce sync.Once
+	metaSchemas     *jsonSchemaValidator
+	metaSchemasErr  error
+)
+
+// ValidateSwagger2Schema checks a JSON encoded document against the official
+// Swagger 2.0 meta-schema.
+func ValidateSwagger2Schema(document []byte) Violations {
+	metaSchemasOnce.Do(func() {
+		metaSchemas, metaSchemasErr = newJSONSchemaValidator(map[string]string{
+			swagger2SchemaID: "schemas/swagger-2.0.json",
+			draft04SchemaID:  "schemas/draft-04.json",
+		})
+	})
+	if metaSchemasErr != nil {
+		return Violations{{Location: "", Message: "meta-schema could not be loaded: " + metaSchemasErr.Error()}}
+	}
+	value, err := DecodeJSON(document)
+	if err != nil {
+		return Violations{{Location: "", Message: "must be valid JSON"}}
+	}
+	var violations Violations
+	metaSchemas.validate("", metaSchemas.documents[swagger2SchemaID], swagger2SchemaID, value, &violations)
+	return violations
+}
+
+// jsonSchemaValidator implements the JSON Schema draft-04 keywords used by
+// the meta-schemas. Formats are not checked, as draft-04 makes them optional.
+type jsonSchemaValidator struct {
+	documents map[string]interface{}
+
+	patternsMux sync.Mutex
+	patterns    map[string]*regexp.Regexp
+}
+
+func newJSONSchemaValidator(files map[string]string) (*jsonSchemaValidator, error) {
+	v := &jsonSchemaValidator{
+		documents: make(map[string]interface{}, len(files)),
+		patterns:  make(map[string]*regexp.Regexp),
+	}
+	for id, name := range files {
+		data, err := metaSchemaFiles.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		document, err := DecodeJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		v.documents[id] = document
+	}
+	return v, nil
+}
+
+// resolve follows a reference relative to the document identified by base
+// and returns the target schema and the document it lives in.
+func (v *jsonSchemaValidator) resolve(ref, base string) (interface{}, string, bool) {
+	id, pointer, _ := strings.Cut(ref, "#")
+	if id == "" {
+		id = base
+	}
+	target, ok := v.documents[id]
+	if !ok {
+		return nil, "", false
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := target.(map[string]interface{})
+		if !ok {
+			return nil, "", false
+		}
+		if target, ok = object[token]; !ok {
+			return nil, "", false
+		}
+	}
+	return target, id, true
+}
+
+func (v *jsonSchemaValidator) validate(location string, schemaValue interface{}, base string, value interface{}, violations *Violations) {
+	schema, ok := schemaValue.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		target, targetBase, ok := v.resolve(ref, base)
+		if !ok {
+			violations.add(location, "unresolvable meta-schema reference %s", ref)
+			return
+		}
+		v.validate(location, target, targetBase, value, violations)
+		return
+	}
+
+	reported := len(*violations)
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		violations.add(location, "must be of type %s", formatTypes(types))
+		return
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !inJSONEnum(enum, value) {
+		violations.add(location, "must be one of %s", formatEnum(enum))
+	}
+
+	switch typed := value.(type) {
+	case string:
+		v.validateJSONString(location, schema, typed, violations)
+	case []interface{}:
+		v.validateJSONArray(location, schema, base, typed, violations)
+	case map[string]interface{}:
+		v.validateJSONObject(location, schema, base, typed, violations)
+	case bool, nil:
+	default:
+		if number, ok := toFloat(value); ok {
+			validateJSONNumber(location, schema, number, violations)
+		}
+	}
+
+	v.validateCombinators(location, schema, base, value, violations, reported)
+}
+
+// validateCombinators checks allOf, anyOf, oneOf and not. Violations before
+// index reported were found elsewhere, the ones after it by this schema.
+func (v *jsonSchemaValidator) validateCombinators(location string, schema map[string]interface{}, base string, value interface{}, violations *Violations, reported int) {
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			v.validate(location, sub, base, value, violations)
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		if matches, closest := v.matchAll(location, anyOf, base, value); matches == 0 {
+			*violations = append(*violations, closest...)
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matches, closest := v.matchAll(location, oneOf, base, value)
+		switch {
+		case matches == 0:
+			*violations = append(*violations, closest...)
+		case matches > 1:
+			violations.add(location, "must match exactly one of the oneOf schemas, matches %d", matches)
+		}
+	}
+	// A value already failing this schema, e.g. empty responses failing
+	// minProperties, is not told again that it matches not
+	if not, ok := schema["not"]; ok && len(*violations) == reported {
+		var sub Violations
+		v.validate(location, not, base, value, &sub)
+		if len(sub) == 0 {
+			violations.add(location, "must not match the schema excluded by not")
+		}
+	}
+}
+
+// matchAll counts the schemas value matches. When none does, it returns the
+// violations of the closest schema, which usually name the actual mistake.
+func (v *jsonSchemaValidator) matchAll(location string, schemas []interface{}, base string, value interface{}) (int, Violations) {
+	matches := 0
+	var closest Violations
+	for _, sub := range schemas {
+		var subViolations Violations
+		v.validate(location, sub, base, value, &subViolations)
+		if len(subViolations) == 0 {
+			matches++
+		} else if closest == nil || len(subViolations) < len(closest) {
+			closest = subViolations
+		}
+	}
+	return matches, closest
+}
+
+func (v *jsonSchemaValidator) validateJSONString(location string, schema map[string]interface{}, value string, violations *Violations) {
+	length := utf8.RuneCountInString(value)
+	if limit, ok := intKeyword(schema, "minLength"); ok && length < limit {
+		violations.add(location, "must be at least %s long", count(limit, "character", "characters"))
+	}
+	if limit, ok := intKeyword(schema, "maxLength"); ok && length > limit {
+		violations.add(location, "must be at most %s long", count(limit, "character", "characters"))
+	}
+	if expr, ok := schema["pattern"].(string); ok {
+		if pattern, err := v.pattern(expr); err == nil && !pattern.MatchString(value) {
+			violations.add(location, "must match pattern %s", expr)
+		}
+	}
+}
+
+func validateJSONNumber(location string, schema map[string]interface{}, value float64, violations *Violations) {
+	if maximum, ok := toFloat(schema["maximum"]); ok {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive && value >= maximum {
+			violations.add(location, "must be less than %v", maximum)
+		} else if value > maximum {
+			violations.add(location, "must be at most %v", maximum)
+		}
+	}
+	if minimum, ok := toFloat(schema["minimum"]); ok {
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive && value <= minimum {
+			violations.add(location, "must be greater than %v", minimum)
+		} else if value < minimum {
+			violations.add(location, "must be at least %v", minimum)
+		}
+	}
+	if multipleOf, ok := toFloat(schema["multipleOf"]); ok && multipleOf != 0 {
+		quotient := value / multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			violations.add(location, "must be a multiple of %v", multipleOf)
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateJSONArray(location string, schema map[string]interface{}, base string, value []interface{}, violations *Violations) {
+	if limit, ok := intKeyword(schema, "minItems"); ok && len(value) < limit {
+		violations.add(location, "must contain at least %s", count(limit, "item", "items"))
+	}
+	if limit, ok := intKeyword(schema, "maxItems"); ok && len(value) > limit {
+		violations.add(location, "must contain at most %s", count(limit, "item", "items"))
+	}
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+	outer:
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if jsonEqual(value[i], value[j]) {
+					violations.add(location, "items must be unique")
+					break outer
+				}
+			}
+		}
+	}
+	switch items := schema["items"].(type) {
+	case map[string]interface{}:
+		for i, item := range value {
+			v.validate(fmt.Sprintf("%s[%d]", location, i), items, base, item, violations)
+		}
+	case []interface{}:
+		for i, item := range value {
+			itemLocation := fmt.Sprintf("%s[%d]", location, i)
+			if i < len(items) {
+				v.validate(itemLocation, items[i], base, item, violations)
+				continue
+			}
+			switch additional := schema["additionalItems"].(type) {
+			case bool:
+				if !additional {
+					violations.add(itemLocation, "is not an allowed item")
+				}
+			case map[string]interface{}:
+				v.validate(itemLocation, additional, base, item, violations)
+			}
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateJSONObject(location string, schema map[string]interface{}, base string, value map[string]interface{}, violations *Violations) {
+	if limit, ok := intKeyword(schema, "minProperties"); ok && len(value) < limit {
+		violations.add(location, "must have at least %s", count(limit, "property", "properties"))
+	}
+	if limit, ok := intKeyword(schema, "maxProperties"); ok && len(value) > limit {
+		violations.add(location, "must have at most %s", count(limit, "property", "properties"))
+	}
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := value[name]; !present {
+					violations.add(joinLocation(location, name), "is required")
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	patternProperties, _ := schema["patternProperties"].(map[string]interface{})
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propLocation := joinLocation(location, name)
+		matched := false
+		if prop, ok := properties[name]; ok {
+			matched = true
+			v.validate(propLocation, prop, base, value[name], violations)
+		}
+		for expr, prop := range patternProperties {
+			if pattern, err := v.pattern(expr); err == nil && pattern.MatchString(name) {
+				matched = true
+				v.validate(propLocation, prop, base, value[name], violations)
+			}
+		}
+		if matched {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				violations.add(propLocation, "is not an allowed property")
+			}
+		case map[string]interface{}:
+			v.validate(propLocation, additional, base, value[name], violations)
+		}
+	}
+
+	if dependencies, ok := schema["dependencies"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(dependencies) {
+			if _, present := value[name]; !present {
+				continue
+			}
+			switch dependency := dependencies[name].(type) {
+			case []interface{}:
+				for _, required := range dependency {
+					if required, ok := required.(string); ok {
+						if _, present := value[required]; !present {
+							violations.add(joinLocation(location, required), "is required by %s", name)
+						}
+					}
+				}
+			case map[string]interface{}:
+				v.validate(location, dependency, base, value, violations)
+			}
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) pattern(expr string) (*regexp.Regexp, error) {
+	v.patternsMux.Lock()
+	defer v.patternsMux.Unlock()
+	if pattern, ok := v.patterns[expr]; ok {
+		return pattern, nil
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	v.patterns[expr] = pattern
+	return pattern, nil
+}
+
+func matchesType(types interface{}, value interface{}) bool {
+	switch types := types.(type) {
+	case string:
+		return isJSONType(types, value)
+	case []interface{}:
+		for _, t := range types {
+			if name, ok := t.(string); ok && isJSONType(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func isJSONType(name string, value interface{}) bool {
+	switch name {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		number, ok := toFloat(value)
+		return ok && number == math.Trunc(number)
+	}
+	return false
+}
+
+func formatTypes(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		return formatEnum(list)
+	}
+	return fmt.Sprint(types)
+}
+
+func intKeyword(schema map[string]interface{}, name string) (int, bool) {
+	number, ok := toFloat(schema[name])
+	return int(number), ok
+}
+
+func inJSONEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if jsonEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEqual compares decoded JSON values, treating numbers by value.
+func jsonEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case []interface{}:
+		other, ok := b.([]interface{})
+		if !ok || len(a) != len(other) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], other[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		other, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(other) {
+			return false
+		}
+		for key, value := range a {
+			otherValue, ok := other[key]
+			if !ok || !jsonEqual(value, otherValue) {
+				return false
+			}
+		}
+		return true
+	}
+	return equalValues(a, b)
+}
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestValidateSwagger2Schema(t *testing.T) {
+	const info = `"info":{"title":"Pet Store","version":"1.0.0"}`
+	tests := []struct {
+		name     string
+		document string
+		want     Violations
+	}{
+		{
+			name:     "minimal document",
+			document: `{"swagger":"2.0",` + info + `,"paths":{}}`,
+		},
+		{
+			name: "operation with parameters and extensions",
+			document: `{"swagger":"2.0",` + info + `,"x-logo":{"url":"logo.png"},"paths":{"/pets/{id}":{"get":{` +
+				`"parameters":[{"name":"id","in":"path","required":true,"type":"integer","format":"int64"}],` +
+				`"responses":{"200":{"description":"A pet","schema":{"$ref":"#/definitions/Pet"}}}}}},` +
+				`"definitions":{"Pet":{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}}}`,
+		},
+		{
+			name:     "missing info",
+			document: `{"swagger":"2.0","paths":{}}`,
+			want:     Violations{{"info", "is required"}},
+		},
+		{
+			name:     "wrong version",
+			document: `{"swagger":"3.0",` + info + `,"paths":{}}`,
+			want:     Violations{{"swagger", "must be one of [2.0]"}},
+		},
+		{
+			name:     "wrong type",
+			document: `{"swagger":"2.0","info":{"title":1,"version":"1.0.0"},"paths":{}}`,
+			want:     Violations{{"info.title", "must be of type string"}},
+		},
+		{
+			name:     "reference that is not a string",
+			document: `{"swagger":"2.0",` + info + `,"paths":{"/pets":{"get":{"responses":{"200":{"description":"OK","schema":{"$ref":1}}}}}}}`,
+			want:     Violations{{"paths./pets.get.responses.200.schema.$ref", "must be of type string"}},
+		},
+		{
+			name:     "unknown schema type",
+			document: `{"swagger":"2.0",` + info + `,"paths":{},"definitions":{"Pet":{"type":"strin"}}}`,
+			want:     Violations{{"definitions.Pet.type", "must be one of [array, boolean, integer, null, number, object, string]"}},
+		},
+		{
+			name:     "nested schema type of the wrong kind",
+			document: `{"swagger":"2.0",` + info + `,"paths":{},"definitions":{"Pet":{"properties":{"tags":{"type":"array","items":{"type":1}}}}}}`,
+			want:     Violations{{"definitions.Pet.properties.tags.items.type", "must be one of [array, boolean, integer, null, number, object, string]"}},
+		},
+		{
+			name: "object query parameter",
+			document: `{"swagger":"2.0",` + info + `,"paths":{"/pets":{"get":{` +
+				`"parameters":[{"name":"filter","in":"query","type":"object"}],"responses":{"200":{"description":"OK"}}}}}}`,
+			want: Violations{{"paths./pets.get.parameters[0].type", "must be one of [string, number, boolean, integer, array]"}},
+		},
+		{
+			name: "duplicate parameters",
+			document: `{"swagger":"2.0",` + info + `,"paths":{"/pets":{"get":{` +
+				`"parameters":[{"name":"q","in":"query","type":"string"},{"name":"q","in":"query","type":"string"}],"responses":{"200":{"description":"OK"}}}}}}`,
+			want: Violations{{"paths./pets.get.parameters", "items must be unique"}},
+		},
+		{
+			name:     "unknown root property",
+			document: `{"swagger":"2.0",` + info + `,"paths":{},"servers":[]}`,
+			want:     Violations{{"servers", "is not an allowed property"}},
+		},
+		{
+			name:     "empty responses",
+			document: `{"swagger":"2.0",` + info + `,"paths":{"/pets":{"get":{"responses":{}}}}}`,
+			want:     Violations{{"paths./pets.get.responses", "must have at least 1 property"}},
+		},
+		{
+			name:     "only extension responses",
+			document: `{"swagger":"2.0",` + info + `,"paths":{"/pets":{"get":{"responses":{"x-note":"none"}}}}}`,
+			want:     Violations{{"paths./pets.get.responses", "must not match the schema excluded by not"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateSwagger2Schema([]byte(tt.document))
+			if got.Error() != tt.want.Error() {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSwagger2SchemaParameterLocation(t *testing.T) {
+	document := `{"swagger":"2.0","info":{"title":"Pet Store","version":"1.0.0"},"paths":{"/pets":{"get":{` +
+		`"parameters":[{"name":"session","in":"cookie","type":"string"}],"responses":{"200":{"description":"OK"}}}}}}`
+	violations := ValidateSwagger2Schema([]byte(document))
+	if len(violations) == 0 || violations[0].Location != "paths./pets.get.parameters[0].in" {
+		t.Errorf("got %q, want a violation of paths./pets.get.parameters[0].in", violations)
+	}
+}
+
+func TestCount(t *testing.T) {
+	for n, want := range map[int]string{0: "0 items", 1: "1 item", 2: "2 items"} {
+		if got := count(n, "item", "items"); got != want {
+			t.Errorf("count(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// Operation is a documented operation together with the parameters that
+// apply to it: path level parameters merged with the operation's own.
+type Operation struct {
+	Path       string
+	Method     string
+	Operation  *openapi_spec.OperationEntity
+	Parameters []openapi_spec.ParameterEntity
+}
+
+// FindOperation looks up the operation documented for method and the path
+// template, matching templates by shape when parameter names differ.
+func FindOperation(doc openapi_spec.SwaggerDocEntity, method, template string) (Operation, bool) {
+	for _, path := range candidatePaths(doc.Paths, template) {
+		item := doc.Paths[path]
+		op := operationFor(item, method)
+		if op == nil {
+			continue
+		}
+		return Operation{
+			Path:       path,
+			Method:     strings.ToUpper(method),
+			Operation:  op,
+			Parameters: mergeParameters(item.Parameters, op.Parameters),
+		}, true
+	}
+	return Operation{}, false
+}
+
+// candidatePaths returns template when it is documented, followed by the
+// documented templates of the same shape in sorted order, so the same
+// operation is picked on every run.
+func candidatePaths(paths map[string]openapi_spec.PathItemEntity, template string) []string {
+	var candidates []string
+	if _, ok := paths[template]; ok {
+		candidates = append(candidates, template)
+	}
+	shape := pathShape(template)
+	var sameShape []string
+	for path := range paths {
+		if path != template && pathShape(path) == shape {
+			sameShape = append(sameShape, path)
+		}
+	}
+	sort.Strings(sameShape)
+	return append(candidates, sameShape...)
+}
+
+func pathShape(path string) string {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func operationFor(item openapi_spec.PathItemEntity, method string) *openapi_spec.OperationEntity {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return item.Get
+	case http.MethodPost:
+		return item.Post
+	case http.MethodPut:
+		return item.Put
+	case http.MethodDelete:
+		return item.Delete
+	case http.MethodOptions:
+		return item.Options
+	case http.MethodHead:
+		return item.Head
+	case http.MethodPatch:
+		return item.Patch
+	}
+	return nil
+}
+
+// mergeParameters applies operation parameters over path level ones with the
+// same name and location.
+func mergeParameters(pathParams, opParams []openapi_spec.ParameterEntity) []openapi_spec.ParameterEntity {
+	merged := make([]openapi_spec.ParameterEntity, 0, len(pathParams)+len(opParams))
+	for _, param := range pathParams {
+		overridden := false
+		for _, own := range opParams {
+			if own.Name == param.Name && own.In == param.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, param)
+		}
+	}
+	return append(merged, opParams...)
+}
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+func TestFindOperation(t *testing.T) {
+	op := func(id string) *openapi_spec.OperationEntity {
+		return &openapi_spec.OperationEntity{OperationID: id}
+	}
+	doc := openapi_spec.SwaggerDocEntity{Paths: map[string]openapi_spec.PathItemEntity{
+		"/pets/{id}":          {Get: op("getPet")},
+		"/pets/{petId}":       {Get: op("getPetById"), Delete: op("deletePet")},
+		"/pets/{name}":        {Get: op("getPetByName")},
+		"/pets/{id}/photos":   {Get: op("listPhotos")},
+		"/owners/{id}/photos": {Get: op("listOwnerPhotos")},
+	}}
+
+	tests := []struct {
+		method, template string
+		want             string
+	}{
+		{"GET", "/pets/{petId}", "getPetById"},
+		{"GET", "/pets/{other}", "getPet"},
+		{"DELETE", "/pets/{id}", "deletePet"},
+		{"get", "/pets/{x}/photos", "listPhotos"},
+		{"GET", "/owners/{ownerId}/photos", "listOwnerPhotos"},
+		{"POST", "/pets/{id}", ""},
+		{"GET", "/pets", ""},
+	}
+	for _, tt := range tests {
+		// Map iteration order varies, repeat to catch nondeterminism
+		for i := 0; i < 20; i++ {
+			found, ok := FindOperation(doc, tt.method, tt.template)
+			got := ""
+			if ok {
+				got = found.Operation.OperationID
+			}
+			if got != tt.want {
+				t.Errorf("FindOperation(%s %s) = %q, want %q", tt.method, tt.template, got, tt.want)
+				break
+			}
+		}
+	}
+}
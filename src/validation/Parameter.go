@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+// ParameterSchema returns the schema described by the inline type and
+// validation fields of a non-body parameter.
+func ParameterSchema(param openapi_spec.ParameterEntity) *openapi_spec.SchemaEntity {
+	if param.Schema != nil {
+		return param.Schema
+	}
+	return &openapi_spec.SchemaEntity{
+		Type:             param.Type,
+		Format:           param.Format,
+		Items:            param.Items,
+		Maximum:          param.Maximum,
+		ExclusiveMaximum: param.ExclusiveMaximum,
+		Minimum:          param.Minimum,
+		ExclusiveMinimum: param.ExclusiveMinimum,
+		MaxLength:        param.MaxLength,
+		MinLength:        param.MinLength,
+		Pattern:          param.Pattern,
+		MaxItems:         param.MaxItems,
+		MinItems:         param.MinItems,
+		UniqueItems:      param.UniqueItems,
+		Enum:             param.Enum,
+		MultipleOf:       param.MultipleOf,
+	}
+}
+
+// ValidateParameter checks the raw string values received for a non-body
+// parameter. raw holds every occurrence of the parameter (several for
+// collectionFormat "multi"); present reports whether it was sent at all.
+func (v *SchemaValidator) ValidateParameter(param openapi_spec.ParameterEntity, raw []string, present bool) Violations {
+	location := param.In + "." + param.Name
+	var violations Violations
+	if !present {
+		if param.Required {
+			violations.add(location, "is required")
+		}
+		return violations
+	}
+	if len(raw) == 0 || (len(raw) == 1 && raw[0] == "") {
+		if param.Required && !param.AllowEmptyValue {
+			violations.add(location, "must not be empty")
+		}
+		return violations
+	}
+
+	schema := ParameterSchema(param)
+	value, ok := parseParameter(schema, param.CollectionFormat, raw)
+	if !ok {
+		violations.add(location, "must be of type %s", schema.Type)
+		return violations
+	}
+	return append(violations, v.Validate(location, schema, value)...)
+}
+
+func parseParameter(schema *openapi_spec.SchemaEntity, collectionFormat string, raw []string) (interface{}, bool) {
+	if schema.Type != "array" {
+		return parseScalar(schema.Type, raw[0])
+	}
+	var parts []string
+	if collectionFormat == "multi" {
+		parts = raw
+	} else {
+		parts = strings.Split(raw[0], collectionSeparator(collectionFormat))
+	}
+	itemType := "string"
+	if schema.Items != nil {
+		itemType = schema.Items.Type
+	}
+	items := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		item, ok := parseScalar(itemType, part)
+		if !ok {
+			return nil, false
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
+func collectionSeparator(collectionFormat string) string {
+	switch collectionFormat {
+	case "ssv":
+		return " "
+	case "tsv":
+		return "\t"
+	case "pipes":
+		return "|"
+	}
+	return ","
+}
+
+func parseScalar(paramType, raw string) (interface{}, bool) {
+	switch paramType {
+	case "integer":
+		value, err := strconv.ParseInt(raw, 10, 64)
+		return value, err == nil
+	case "number":
+		value, err := strconv.ParseFloat(raw, 64)
+		return value, err == nil
+	case "boolean":
+		value, err := strconv.ParseBool(raw)
+		return value, err == nil
+	}
+	return raw, true
+}
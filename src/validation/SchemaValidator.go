@@ -0,0 +1,444 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+const definitionsRefPrefix = "#/definitions/"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Direction tells the validator which side of the exchange a value is on:
+// readOnly properties are never required in requests.
+type Direction int
+
+const (
+	Request Direction = iota
+	Response
+)
+
+// SchemaValidator validates decoded JSON values against Swagger schemas,
+// resolving "#/definitions/..." references against the document.
+type SchemaValidator struct {
+	definitions map[string]openapi_spec.SchemaEntity
+	direction   Direction
+
+	patternsMux sync.Mutex
+	patterns    map[string]*regexp.Regexp
+}
+
+func NewSchemaValidator(definitions map[string]openapi_spec.SchemaEntity, direction Direction) *SchemaValidator {
+	return &SchemaValidator{
+		definitions: definitions,
+		direction:   direction,
+		patterns:    make(map[string]*regexp.Regexp),
+	}
+}
+
+// Validate checks value, as decoded by encoding/json (preferably with
+// UseNumber), against schema and returns every violation found.
+func (v *SchemaValidator) Validate(location string, schema *openapi_spec.SchemaEntity, value interface{}) Violations {
+	var violations Violations
+	v.validate(location, schema, value, &violations, 0)
+	return violations
+}
+
+// DecodeJSON decodes data keeping numbers as json.Number, the form expected by Validate.
+func DecodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (v *SchemaValidator) validate(location string, schema *openapi_spec.SchemaEntity, value interface{}, violations *Violations, depth int) {
+	if schema == nil || depth > 64 {
+		return
+	}
+	if schema.Ref != "" {
+		resolved, ok := v.resolve(schema.Ref)
+		if !ok {
+			violations.add(location, "unresolvable schema reference %s", schema.Ref)
+			return
+		}
+		if value == nil && schema.Nullable {
+			return
+		}
+		if subtype, ok := v.subtype(location, schema.Ref, resolved, value, violations); ok {
+			resolved = subtype
+		}
+		v.validate(location, v.inlineBases(resolved), value, violations, depth+1)
+		return
+	}
+	if value == nil && schema.Nullable {
+		return
+	}
+	for _, sub := range schema.AllOf {
+		v.validate(location, sub, value, violations, depth+1)
+	}
+
+	if value == nil {
+		if schema.Type != "" && !schema.Nullable && !v.nilEncoded(schema) {
+			violations.add(location, "must not be null")
+		}
+		return
+	}
+
+	if !v.validateType(location, schema, value, violations) {
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		violations.add(location, "must be one of %s", formatEnum(schema.Enum))
+	}
+
+	switch typed := value.(type) {
+	case string:
+		v.validateString(location, schema, typed, violations)
+	case []interface{}:
+		v.validateArray(location, schema, typed, violations, depth)
+	case map[string]interface{}:
+		v.validateObject(location, schema, typed, violations, depth)
+	case bool:
+	default:
+		if number, ok := toFloat(value); ok {
+			validateNumber(location, schema, number, violations)
+		}
+	}
+}
+
+// nilEncoded reports whether null is how a response encodes an empty value
+// of schema: encoding/json writes nil slices and maps as null.
+func (v *SchemaValidator) nilEncoded(schema *openapi_spec.SchemaEntity) bool {
+	if v.direction != Response {
+		return false
+	}
+	switch schema.Type {
+	case "array":
+		return true
+	case "object":
+		return schema.AdditionalProperties != nil && len(schema.Properties) == 0
+	}
+	return false
+}
+
+func (v *SchemaValidator) resolve(ref string) (*openapi_spec.SchemaEntity, bool) {
+	if !strings.HasPrefix(ref, definitionsRefPrefix) {
+		return nil, false
+	}
+	definition, ok := v.definitions[strings.TrimPrefix(ref, definitionsRefPrefix)]
+	return &definition, ok
+}
+
+// subtype returns the definition a polymorphic definition dispatches an
+// object to: the one its discriminator property names, which must extend
+// the base through allOf. Objects without a string discriminator are left
+// to the base.
+func (v *SchemaValidator) subtype(location, ref string, base *openapi_spec.SchemaEntity, value interface{}, violations *Violations) (*openapi_spec.SchemaEntity, bool) {
+	object, ok := value.(map[string]interface{})
+	if !ok || base.Discriminator == "" {
+		return nil, false
+	}
+	baseName := strings.TrimPrefix(ref, definitionsRefPrefix)
+	name, ok := object[base.Discriminator].(string)
+	if !ok || name == baseName {
+		return nil, false
+	}
+	if definition, ok := v.definitions[name]; ok {
+		for _, part := range definition.AllOf {
+			if part != nil && part.Ref == ref {
+				return &definition, true
+			}
+		}
+	}
+	violations.add(joinLocation(location, base.Discriminator), "must name a definition extending %s", baseName)
+	return nil, false
+}
+
+// inlineBases returns schema with the polymorphic bases of its allOf
+// resolved and stripped of their discriminator, so that an object reaching
+// them through a subtype is not dispatched again.
+func (v *SchemaValidator) inlineBases(schema *openapi_spec.SchemaEntity) *openapi_spec.SchemaEntity {
+	var allOf []*openapi_spec.SchemaEntity
+	for i, part := range schema.AllOf {
+		if part == nil || part.Ref == "" {
+			continue
+		}
+		base, ok := v.resolve(part.Ref)
+		if !ok || base.Discriminator == "" {
+			continue
+		}
+		if allOf == nil {
+			allOf = append([]*openapi_spec.SchemaEntity(nil), schema.AllOf...)
+		}
+		base.Discriminator = ""
+		allOf[i] = base
+	}
+	if allOf == nil {
+		return schema
+	}
+	inlined := *schema
+	inlined.AllOf = allOf
+	return &inlined
+}
+
+func (v *SchemaValidator) validateType(location string, schema *openapi_spec.SchemaEntity, value interface{}, violations *Violations) bool {
+	valid := true
+	switch schema.Type {
+	case "":
+		return true
+	case "string":
+		_, valid = value.(string)
+	case "integer":
+		number, ok := toFloat(value)
+		valid = ok && number == math.Trunc(number)
+	case "number":
+		_, valid = toFloat(value)
+	case "boolean":
+		_, valid = value.(bool)
+	case "array":
+		_, valid = value.([]interface{})
+	case "object":
+		_, valid = value.(map[string]interface{})
+	case "file":
+		return false
+	}
+	if !valid {
+		violations.add(location, "must be of type %s", schema.Type)
+	}
+	return valid
+}
+
+func (v *SchemaValidator) validateString(location string, schema *openapi_spec.SchemaEntity, value string, violations *Violations) {
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		violations.add(location, "must be at least %s long", count(*schema.MinLength, "character", "characters"))
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		violations.add(location, "must be at most %s long", count(*schema.MaxLength, "character", "characters"))
+	}
+	if schema.Pattern != "" {
+		if pattern, err := v.pattern(schema.Pattern); err == nil && !pattern.MatchString(value) {
+			violations.add(location, "must match pattern %s", schema.Pattern)
+		}
+	}
+	if message := checkFormat(schema.Format, value); message != "" {
+		violations.add(location, "%s", message)
+	}
+}
+
+func (v *SchemaValidator) pattern(expr string) (*regexp.Regexp, error) {
+	v.patternsMux.Lock()
+	defer v.patternsMux.Unlock()
+	if pattern, ok := v.patterns[expr]; ok {
+		return pattern, nil
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	v.patterns[expr] = pattern
+	return pattern, nil
+}
+
+func checkFormat(format, value string) string {
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return "must be an RFC 3339 date-time"
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return "must be a date (YYYY-MM-DD)"
+		}
+	case "email":
+		if _, err := mail.ParseAddress(value); err != nil {
+			return "must be an email address"
+		}
+	case "uuid":
+		if !uuidPattern.MatchString(value) {
+			return "must be a UUID"
+		}
+	}
+	return ""
+}
+
+func validateNumber(location string, schema *openapi_spec.SchemaEntity, value float64, violations *Violations) {
+	if schema.Maximum != nil {
+		if schema.ExclusiveMaximum && value >= *schema.Maximum {
+			violations.add(location, "must be less than %v", *schema.Maximum)
+		} else if value > *schema.Maximum {
+			violations.add(location, "must be at most %v", *schema.Maximum)
+		}
+	}
+	if schema.Minimum != nil {
+		if schema.ExclusiveMinimum && value <= *schema.Minimum {
+			violations.add(location, "must be greater than %v", *schema.Minimum)
+		} else if value < *schema.Minimum {
+			violations.add(location, "must be at least %v", *schema.Minimum)
+		}
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf != 0 {
+		quotient := value / *schema.MultipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			violations.add(location, "must be a multiple of %v", *schema.MultipleOf)
+		}
+	}
+	switch schema.Format {
+	case "int32":
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			violations.add(location, "must fit in a 32-bit integer")
+		}
+	case "int64":
+		if value < math.MinInt64 || value > math.MaxInt64 {
+			violations.add(location, "must fit in a 64-bit integer")
+		}
+	}
+}
+
+func (v *SchemaValidator) validateArray(location string, schema *openapi_spec.SchemaEntity, value []interface{}, violations *Violations, depth int) {
+	if schema.MinItems != nil && len(value) < *schema.MinItems {
+		violations.add(location, "must contain at least %s", count(*schema.MinItems, "item", "items"))
+	}
+	if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+		violations.add(location, "must contain at most %s", count(*schema.MaxItems, "item", "items"))
+	}
+	if schema.UniqueItems {
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if equalValues(value[i], value[j]) {
+					violations.add(location, "items must be unique")
+					i = len(value)
+					break
+				}
+			}
+		}
+	}
+	for i, item := range value {
+		v.validate(fmt.Sprintf("%s[%d]", location, i), schema.Items, item, violations, depth+1)
+	}
+}
+
+func (v *SchemaValidator) validateObject(location string, schema *openapi_spec.SchemaEntity, value map[string]interface{}, violations *Violations, depth int) {
+	if schema.MinProperties != nil && len(value) < *schema.MinProperties {
+		violations.add(location, "must have at least %s", count(*schema.MinProperties, "property", "properties"))
+	}
+	if schema.MaxProperties != nil && len(value) > *schema.MaxProperties {
+		violations.add(location, "must have at most %s", count(*schema.MaxProperties, "property", "properties"))
+	}
+	for _, name := range schema.Required {
+		if _, ok := value[name]; ok {
+			continue
+		}
+		if prop := schema.Properties[name]; prop != nil && prop.ReadOnly && v.direction == Request {
+			continue
+		}
+		violations.add(joinLocation(location, name), "is required")
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propValue := value[name]
+		if propValue == nil && schema.NullableProperties[name] {
+			continue
+		}
+		if prop, ok := schema.Properties[name]; ok {
+			v.validate(joinLocation(location, name), prop, propValue, violations, depth+1)
+			continue
+		}
+		switch additional := schema.AdditionalProperties.(type) {
+		case bool:
+			if !additional {
+				violations.add(joinLocation(location, name), "is not an allowed property")
+			}
+		case *openapi_spec.SchemaEntity:
+			v.validate(joinLocation(location, name), additional, propValue, violations, depth+1)
+		case openapi_spec.SchemaEntity:
+			v.validate(joinLocation(location, name), &additional, propValue, violations, depth+1)
+		}
+	}
+}
+
+func joinLocation(location, name string) string {
+	if location == "" {
+		return name
+	}
+	return location + "." + name
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case json.Number:
+		f, err := number.Float64()
+		return f, err == nil
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// normalize converts value to the type decoded JSON has, so numbers of any
+// type and values of named types compare equal to their decoded form.
+func normalize(value interface{}) interface{} {
+	if number, ok := toFloat(value); ok {
+		return number
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	}
+	return value
+}
+
+func equalValues(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if equalValues(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		values = append(values, fmt.Sprint(value))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
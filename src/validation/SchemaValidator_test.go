@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/ruiborda/go-swagger-generator/src/openapi_spec"
+)
+
+func TestValidateDiscriminator(t *testing.T) {
+	ref := func(name string) *openapi_spec.SchemaEntity {
+		return &openapi_spec.SchemaEntity{Ref: definitionsRefPrefix + name}
+	}
+	number := &openapi_spec.SchemaEntity{Type: "number"}
+	definitions := map[string]openapi_spec.SchemaEntity{
+		"Shape": {
+			Type:          "object",
+			Discriminator: "kind",
+			Required:      []string{"kind"},
+			Properties:    map[string]*openapi_spec.SchemaEntity{"kind": {Type: "string"}, "label": {Type: "string", MaxLength: intPtr(8)}},
+		},
+		"Circle": {AllOf: []*openapi_spec.SchemaEntity{ref("Shape"), {
+			Type:       "object",
+			Required:   []string{"radius"},
+			Properties: map[string]*openapi_spec.SchemaEntity{"radius": number},
+		}}},
+		"Square": {AllOf: []*openapi_spec.SchemaEntity{ref("Shape"), {
+			Type:       "object",
+			Required:   []string{"side"},
+			Properties: map[string]*openapi_spec.SchemaEntity{"side": number},
+		}}},
+		"Point": {Type: "object"},
+	}
+	shapes := &openapi_spec.SchemaEntity{Type: "array", Items: ref("Shape")}
+	tests := []struct {
+		name   string
+		schema *openapi_spec.SchemaEntity
+		body   string
+		want   Violations
+	}{
+		{"circle", ref("Shape"), `{"kind":"Circle","radius":1}`, nil},
+		{"square", ref("Shape"), `{"kind":"Square","side":2}`, nil},
+		{"subtype properties", ref("Shape"), `{"kind":"Circle","side":2}`, Violations{{"body.radius", "is required"}}},
+		{"base properties", ref("Shape"), `{"kind":"Square","side":"2","label":"a long label"}`, Violations{
+			{"body.label", "must be at most 8 characters long"},
+			{"body.side", "must be of type number"},
+		}},
+		{"unknown type", ref("Shape"), `{"kind":"Triangle"}`, Violations{{"body.kind", "must name a definition extending Shape"}}},
+		{"definition not extending the base", ref("Shape"), `{"kind":"Point"}`, Violations{{"body.kind", "must name a definition extending Shape"}}},
+		{"base itself", ref("Shape"), `{"kind":"Shape"}`, nil},
+		{"missing discriminator", ref("Shape"), `{"radius":1}`, Violations{{"body.kind", "is required"}}},
+		{"discriminator of the wrong type", ref("Shape"), `{"kind":1}`, Violations{{"body.kind", "must be of type string"}}},
+		{"subtype referenced directly", ref("Circle"), `{"kind":"Circle"}`, Violations{{"body.radius", "is required"}}},
+		{"array items", shapes, `[{"kind":"Circle","radius":1},{"kind":"Square"}]`, Violations{{"body[1].side", "is required"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := DecodeJSON([]byte(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := NewSchemaValidator(definitions, Request).Validate("body", tt.schema, value)
+			if got.Error() != tt.want.Error() {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int { return &i }
@@ -0,0 +1,40 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violation is a single mismatch between a value and the document, located
+// by where the value came from, e.g. "query.limit" or "body.tags[0].name".
+type Violation struct {
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return v.Location + ": " + v.Message
+}
+
+// Violations is a list of violations usable as an error.
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, violation := range v {
+		messages = append(messages, violation.String())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (v *Violations) add(location, format string, args ...interface{}) {
+	*v = append(*v, Violation{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// count spells n with the singular or plural form of a noun, e.g. "1 item".
+func count(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}